@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/openlearnnitj/openlearn-monitoring/internal/alerting"
+	"github.com/openlearnnitj/openlearn-monitoring/internal/config"
+	"github.com/openlearnnitj/openlearn-monitoring/internal/handler"
+	"github.com/openlearnnitj/openlearn-monitoring/internal/monitoring"
+	"github.com/openlearnnitj/openlearn-monitoring/internal/storage"
+)
+
+// Invocation triggers
+const (
+	triggerSchedule = "schedule"
+	triggerManual   = "manual"
+)
+
+// Response is returned to the Lambda caller after a monitoring run
+type Response struct {
+	Trigger string `json:"trigger"`
+	*handler.Summary
+}
+
+func main() {
+	// Initialize configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	// Initialize storage backend
+	backend, err := storage.NewBackend(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize %s storage backend: %v", cfg.StorageBackend, err)
+	}
+
+	// Initialize services
+	monitoringService := monitoring.NewService(cfg.Targets)
+	storageService := storage.NewService(backend, cfg.Retention())
+
+	// Initialize alerting service
+	notifiers, err := alerting.NewNotifiers(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize alert channels: %v", err)
+	}
+	alertingService := alerting.NewService(notifiers)
+
+	// Initialize handler
+	h := handler.NewHandler(monitoringService, storageService, alertingService)
+
+	lambda.Start(func(ctx context.Context, payload json.RawMessage) (*Response, error) {
+		trigger := detectTrigger(payload)
+		log.Printf("Invoked by %s trigger", trigger)
+
+		summary, err := h.Handle(ctx)
+		return respond(trigger, summary, err)
+	})
+}
+
+// respond reports a monitoring run. Targets whose results couldn't be stored
+// are listed in the summary's errors; the run only fails when nothing was
+// stored, since Lambda retries failed asynchronous invocations and would
+// probe and alert on the stored targets again.
+func respond(trigger string, summary *handler.Summary, err error) (*Response, error) {
+	if err != nil {
+		if summary == nil || summary.Components == 0 {
+			return nil, fmt.Errorf("monitoring run failed: %w", err)
+		}
+		log.Printf("Monitoring run partially failed: %v", err)
+	}
+
+	return &Response{
+		Trigger: trigger,
+		Summary: summary,
+	}, nil
+}
+
+// detectTrigger tells a scheduled CloudWatch event apart from a manual
+// invocation (e.g. `aws lambda invoke --payload '{}'`)
+func detectTrigger(payload json.RawMessage) string {
+	var event events.CloudWatchEvent
+	if err := json.Unmarshal(payload, &event); err == nil &&
+		event.Source == "aws.events" && event.DetailType == "Scheduled Event" {
+		return triggerSchedule
+	}
+	return triggerManual
+}
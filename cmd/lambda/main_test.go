@@ -0,0 +1,65 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/handler"
+)
+
+func TestDetectTrigger(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    string
+	}{
+		{
+			name:    "scheduled event",
+			payload: `{"version":"0","id":"1","detail-type":"Scheduled Event","source":"aws.events","time":"2025-06-15T12:00:00Z","detail":{}}`,
+			want:    triggerSchedule,
+		},
+		{"empty manual payload", `{}`, triggerManual},
+		{"other event source", `{"detail-type":"Scheduled Event","source":"custom.app"}`, triggerManual},
+		{"non-object payload", `"run"`, triggerManual},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectTrigger(json.RawMessage(tt.payload)); got != tt.want {
+				t.Errorf("detectTrigger() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRespond(t *testing.T) {
+	failed := errors.New("1 of 2 targets failed")
+
+	tests := []struct {
+		name    string
+		summary *handler.Summary
+		err     error
+		wantErr bool
+	}{
+		{"all stored", &handler.Summary{Components: 2}, nil, false},
+		{"some stored", &handler.Summary{Components: 1, Errors: map[string]string{"auth": "failed to store results"}}, failed, false},
+		{"nothing stored", &handler.Summary{Errors: map[string]string{"api": "failed to store results"}}, failed, true},
+		{"no summary", nil, failed, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := respond(triggerSchedule, tt.summary, tt.err)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("respond() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if response.Trigger != triggerSchedule || response.Summary != tt.summary {
+				t.Errorf("respond() = %+v, want the %s summary", response, triggerSchedule)
+			}
+		})
+	}
+}
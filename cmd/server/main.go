@@ -1,39 +1,68 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"fmt"
 	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
+	"github.com/openlearnnitj/openlearn-monitoring/internal/alerting"
 	"github.com/openlearnnitj/openlearn-monitoring/internal/config"
 	"github.com/openlearnnitj/openlearn-monitoring/internal/handler"
+	"github.com/openlearnnitj/openlearn-monitoring/internal/leader"
 	"github.com/openlearnnitj/openlearn-monitoring/internal/monitoring"
+	"github.com/openlearnnitj/openlearn-monitoring/internal/scheduler"
 	"github.com/openlearnnitj/openlearn-monitoring/internal/storage"
 )
 
+// monitorTimeout bounds a /monitor run, like the Lambda timeout does for
+// scheduled invocations
+const monitorTimeout = 60 * time.Second
+
+// shutdownTimeout bounds how long in-flight requests get to finish on SIGTERM
+const shutdownTimeout = 10 * time.Second
+
 func main() {
+	configPath := flag.String("config", os.Getenv("CONFIG_FILE"), "path to a YAML or JSON config file")
+	schedule := flag.Bool("schedule", true, "check targets on their intervals; disable when an external scheduler calls /monitor")
+	flag.Parse()
+
 	// Initialize configuration
-	cfg, err := config.LoadConfig()
+	cfg, err := config.Load(*configPath)
 	if err != nil {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
-	// Initialize storage client
-	storageClient, err := storage.NewDynamoDBClient(cfg.AWSRegion)
+	// Initialize storage backend
+	backend, err := storage.NewBackend(cfg)
 	if err != nil {
-		log.Fatalf("Failed to initialize DynamoDB client: %v", err)
+		log.Fatalf("Failed to initialize %s storage backend: %v", cfg.StorageBackend, err)
 	}
 
 	// Initialize monitoring service
-	monitoringService := monitoring.NewService(cfg.MonitoringAPIURL, cfg.MonitoringAPISecret)
+	monitoringService := monitoring.NewService(cfg.Targets)
 
 	// Initialize storage service
-	storageService := storage.NewService(storageClient, cfg.DynamoDBTableName)
+	storageService := storage.NewService(backend, cfg.Retention())
+
+	// Initialize alerting service
+	notifiers, err := alerting.NewNotifiers(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize alert channels: %v", err)
+	}
+	alertingService := alerting.NewService(notifiers)
 
 	// Initialize handler
-	h := handler.NewHandler(monitoringService, storageService)
+	h := handler.NewHandler(monitoringService, storageService, alertingService)
 
 	// Create Fiber app
 	app := fiber.New(fiber.Config{
@@ -63,23 +92,69 @@ func main() {
 		})
 	})
 
-	// Trigger monitoring endpoint
+	// Run checks on their own intervals until SIGTERM or SIGINT
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
+
+	var wg sync.WaitGroup
+	if *schedule {
+		s := scheduler.NewScheduler(cfg.Targets, func(ctx context.Context, target config.Target) {
+			h.CheckTarget(ctx, target)
+		}, cfg.SchedulerConcurrency)
+		run := s.Run
+
+		// With several replicas, only the lease holder runs the scheduler
+		if cfg.LeaderElection {
+			leases, ok := backend.(storage.Leases)
+			if !ok {
+				log.Fatalf("The %s storage backend doesn't support leader election", cfg.StorageBackend)
+			}
+			elector := leader.NewElector(leases, leader.LeaseName, replicaID(), cfg.LeaseTTL)
+			run = func(ctx context.Context) {
+				elector.Run(ctx, s.Run)
+			}
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			run(ctx)
+		}()
+	}
+
+	go func() {
+		<-ctx.Done()
+		log.Println("Shutting down")
+		if err := app.ShutdownWithTimeout(shutdownTimeout); err != nil {
+			log.Printf("Failed to shut down server: %v", err)
+		}
+	}()
+
+	// Trigger monitoring endpoint, running every check now
 	app.Post("/monitor", func(c *fiber.Ctx) error {
-		if err := h.Handle(c.Context()); err != nil {
+		ctx, cancel := context.WithTimeout(c.UserContext(), monitorTimeout)
+		defer cancel()
+		summary, err := h.Handle(ctx)
+		if err != nil {
 			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 		}
 		return c.JSON(fiber.Map{
 			"message": "Monitoring completed successfully",
+			"summary": summary,
 		})
 	})
 
 	// Scheduled monitoring endpoint (for external schedulers like cron)
 	app.Get("/monitor", func(c *fiber.Ctx) error {
-		if err := h.Handle(c.Context()); err != nil {
+		ctx, cancel := context.WithTimeout(c.UserContext(), monitorTimeout)
+		defer cancel()
+		summary, err := h.Handle(ctx)
+		if err != nil {
 			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 		}
 		return c.JSON(fiber.Map{
 			"message": "Monitoring completed successfully",
+			"summary": summary,
 		})
 	})
 
@@ -90,5 +165,19 @@ func main() {
 	}
 	
 	log.Printf("Starting Fiber server on port %s", port)
-	log.Fatal(app.Listen(":" + port))
+	if err := app.Listen(":" + port); err != nil {
+		log.Fatal(err)
+	}
+
+	// Let scheduled checks store their results before exiting
+	wg.Wait()
+}
+
+// replicaID identifies this process as a lease holder
+func replicaID() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s-%d", hostname, os.Getpid())
 }
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"flag"
 	"log"
+	"os"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
@@ -15,20 +17,23 @@ import (
 )
 
 func main() {
+	configPath := flag.String("config", os.Getenv("CONFIG_FILE"), "path to a YAML or JSON config file")
+	flag.Parse()
+
 	// Load configuration
-	cfg, err := config.LoadConfig()
+	cfg, err := config.Load(*configPath)
 	if err != nil {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
-	// Initialize DynamoDB client
-	dynamoClient, err := storage.NewDynamoDBClient(cfg.AWSRegion)
+	// Initialize storage backend
+	backend, err := storage.NewBackend(cfg)
 	if err != nil {
-		log.Fatalf("Failed to initialize DynamoDB client: %v", err)
+		log.Fatalf("Failed to initialize %s storage backend: %v", cfg.StorageBackend, err)
 	}
 
 	// Initialize status service
-	statusService := status.NewStatusService(dynamoClient.GetClient(), cfg.DynamoDBTableName)
+	statusService := status.NewStatusService(backend)
 
 	// Initialize template engine with custom functions
 	engine := html.New("./web/templates", ".html")
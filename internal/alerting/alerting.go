@@ -0,0 +1,157 @@
+package alerting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/config"
+	"github.com/openlearnnitj/openlearn-monitoring/internal/models"
+)
+
+// Alert is a component changing status between two stored checks
+type Alert struct {
+	Component      string
+	PreviousStatus string // empty for a component checked for the first time
+	Status         string
+	Message        string
+	ResponseTimeMs float64
+	Timestamp      time.Time
+	// OutageStart is when the outage the alert is about, or ended by,
+	// began; zero if that's unknown. OutageDuration is set on recovery.
+	OutageStart    time.Time
+	OutageDuration time.Duration
+}
+
+// Recovered reports whether the alert is a component becoming operational again
+func (a Alert) Recovered() bool {
+	return a.Status == models.StatusOperational && a.PreviousStatus != ""
+}
+
+// Summary describes the alert in one line
+func (a Alert) Summary() string {
+	if a.Recovered() {
+		if a.OutageDuration > 0 {
+			return fmt.Sprintf("%s is %s again after %s (was %s)", a.Component, a.Status, a.OutageDuration, a.PreviousStatus)
+		}
+		return fmt.Sprintf("%s is %s again (was %s)", a.Component, a.Status, a.PreviousStatus)
+	}
+
+	summary := fmt.Sprintf("%s is %s", a.Component, a.Status)
+	if a.PreviousStatus != "" {
+		summary += fmt.Sprintf(" (was %s)", a.PreviousStatus)
+	}
+	if a.Message != "" {
+		summary += ": " + a.Message
+	}
+	return summary
+}
+
+// Notifier sends alerts to one channel
+type Notifier interface {
+	Notify(ctx context.Context, alert Alert) error
+}
+
+// NewNotifiers creates a notifier for each configured alert channel
+func NewNotifiers(cfg *config.Config) ([]Notifier, error) {
+	notifiers := make([]Notifier, 0, len(cfg.AlertChannels))
+	for i, channel := range cfg.AlertChannels {
+		switch channel.Type {
+		case config.ChannelLog:
+			notifiers = append(notifiers, NewLogNotifier())
+		case config.ChannelWebhook:
+			notifiers = append(notifiers, NewWebhookNotifier(channel, cfg.StatusPageURL))
+		default:
+			return nil, fmt.Errorf("alert channel %d: unsupported channel type %q", i+1, channel.Type)
+		}
+	}
+	return notifiers, nil
+}
+
+// Service detects components changing status and alerts the configured channels
+type Service struct {
+	notifiers []Notifier
+}
+
+// NewService creates a new alerting service
+func NewService(notifiers []Notifier) *Service {
+	return &Service{notifiers: notifiers}
+}
+
+// Notify alerts every channel about the components of a stored result whose
+// status differs from their previous stored check. Every alert is sent to
+// every channel even if some fail; the failures are returned together.
+func (s *Service) Notify(ctx context.Context, previous map[string]models.CheckRecord, result *models.MonitoringResult) error {
+	alerts := Transitions(previous, result)
+	if len(alerts) == 0 || len(s.notifiers) == 0 {
+		return nil
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	for _, notifier := range s.notifiers {
+		for _, alert := range alerts {
+			wg.Add(1)
+			go func(notifier Notifier, alert Alert) {
+				defer wg.Done()
+				if err := notifier.Notify(ctx, alert); err != nil {
+					mu.Lock()
+					errs = append(errs, fmt.Errorf("failed to send alert for %s: %w", alert.Component, err))
+					mu.Unlock()
+				}
+			}(notifier, alert)
+		}
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// Transitions compares the components of a result with their previous
+// stored checks, by name, and returns an alert for each that changed
+// status. A component without a previous check is only alerted on when it
+// isn't operational.
+func Transitions(previous map[string]models.CheckRecord, result *models.MonitoringResult) []Alert {
+	timestamp := result.Timestamp.UTC()
+
+	var alerts []Alert
+	for _, component := range result.Components {
+		before, seen := previous[component.Name]
+		if seen && before.Status == component.Status {
+			continue
+		}
+		if !seen && component.Status == models.StatusOperational {
+			continue
+		}
+
+		alert := Alert{
+			Component:      component.Name,
+			PreviousStatus: before.Status,
+			Status:         component.Status,
+			Message:        component.Message,
+			ResponseTimeMs: component.ResponseTimeMs,
+			Timestamp:      timestamp,
+		}
+
+		// StatusSince of a check that wasn't operational is when its outage began
+		outageStart := before.StatusSince
+		if !seen || before.Status == models.StatusOperational {
+			outageStart = timestamp
+		}
+		if component.Status != models.StatusOperational {
+			alert.OutageStart = outageStart
+		} else if !outageStart.IsZero() {
+			alert.OutageStart = outageStart
+			alert.OutageDuration = timestamp.Sub(outageStart)
+		}
+
+		alerts = append(alerts, alert)
+	}
+
+	return alerts
+}
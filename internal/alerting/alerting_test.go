@@ -0,0 +1,172 @@
+package alerting
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/models"
+)
+
+var testNow = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func TestTransitions(t *testing.T) {
+	outageStart := testNow.Add(-30 * time.Minute)
+
+	tests := []struct {
+		name         string
+		previous     *models.CheckRecord
+		status       string
+		wantAlert    bool
+		wantStart    time.Time
+		wantDuration time.Duration
+	}{
+		{
+			name:     "unchanged",
+			previous: &models.CheckRecord{Status: "OPERATIONAL", StatusSince: outageStart},
+			status:   "OPERATIONAL",
+		},
+		{
+			name:      "goes down",
+			previous:  &models.CheckRecord{Status: "OPERATIONAL", StatusSince: outageStart},
+			status:    "DOWN",
+			wantAlert: true,
+			wantStart: testNow,
+		},
+		{
+			name:      "worsens during an outage",
+			previous:  &models.CheckRecord{Status: "DEGRADED", StatusSince: outageStart},
+			status:    "DOWN",
+			wantAlert: true,
+			wantStart: outageStart,
+		},
+		{
+			name:         "recovers",
+			previous:     &models.CheckRecord{Status: "DOWN", StatusSince: outageStart},
+			status:       "OPERATIONAL",
+			wantAlert:    true,
+			wantStart:    outageStart,
+			wantDuration: 30 * time.Minute,
+		},
+		{
+			name:      "recovers from an outage of unknown length",
+			previous:  &models.CheckRecord{Status: "DOWN"},
+			status:    "OPERATIONAL",
+			wantAlert: true,
+		},
+		{
+			name:   "new and operational",
+			status: "OPERATIONAL",
+		},
+		{
+			name:      "new and down",
+			status:    "DOWN",
+			wantAlert: true,
+			wantStart: testNow,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previous := make(map[string]models.CheckRecord)
+			if tt.previous != nil {
+				previous["api"] = *tt.previous
+			}
+			result := &models.MonitoringResult{
+				Components: []models.Component{{Name: "api", Status: tt.status}},
+				Timestamp:  testNow,
+			}
+
+			alerts := Transitions(previous, result)
+			if !tt.wantAlert {
+				if len(alerts) != 0 {
+					t.Errorf("Transitions() = %+v, want no alerts", alerts)
+				}
+				return
+			}
+			if len(alerts) != 1 {
+				t.Fatalf("Transitions() = %+v, want 1 alert", alerts)
+			}
+
+			alert := alerts[0]
+			if alert.Status != tt.status || !alert.OutageStart.Equal(tt.wantStart) || alert.OutageDuration != tt.wantDuration {
+				t.Errorf("alert = %+v, want %s with outage since %s lasting %s", alert, tt.status, tt.wantStart, tt.wantDuration)
+			}
+		})
+	}
+}
+
+func TestAlertSummary(t *testing.T) {
+	tests := []struct {
+		alert Alert
+		want  string
+	}{
+		{
+			alert: Alert{Component: "api/Database", PreviousStatus: "OPERATIONAL", Status: "DOWN", Message: "connection refused"},
+			want:  "api/Database is DOWN (was OPERATIONAL): connection refused",
+		},
+		{
+			alert: Alert{Component: "cdn", Status: "DEGRADED"},
+			want:  "cdn is DEGRADED",
+		},
+		{
+			alert: Alert{Component: "cdn", PreviousStatus: "DOWN", Status: "OPERATIONAL", OutageDuration: 90 * time.Second},
+			want:  "cdn is OPERATIONAL again after 1m30s (was DOWN)",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := tt.alert.Summary(); got != tt.want {
+			t.Errorf("Summary() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+// notifierFunc adapts a function to the Notifier interface
+type notifierFunc func(ctx context.Context, alert Alert) error
+
+func (f notifierFunc) Notify(ctx context.Context, alert Alert) error {
+	return f(ctx, alert)
+}
+
+func TestNotify(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		sent []string
+	)
+	working := notifierFunc(func(ctx context.Context, alert Alert) error {
+		mu.Lock()
+		defer mu.Unlock()
+		sent = append(sent, alert.Component)
+		return nil
+	})
+	failing := notifierFunc(func(ctx context.Context, alert Alert) error {
+		return errors.New("channel unreachable")
+	})
+
+	previous := map[string]models.CheckRecord{
+		"api":  {Status: "OPERATIONAL"},
+		"auth": {Status: "OPERATIONAL"},
+		"cdn":  {Status: "OPERATIONAL"},
+	}
+	result := &models.MonitoringResult{
+		Components: []models.Component{
+			{Name: "api", Status: "DOWN"},
+			{Name: "auth", Status: "OPERATIONAL"},
+			{Name: "cdn", Status: "DEGRADED"},
+		},
+		Timestamp: testNow,
+	}
+
+	// A failing channel doesn't keep alerts from the others
+	err := NewService([]Notifier{failing, working}).Notify(context.Background(), previous, result)
+	if err == nil || !strings.Contains(err.Error(), "channel unreachable") {
+		t.Errorf("Notify() error = %v, want the failing channel's error", err)
+	}
+	if len(sent) != 2 {
+		t.Errorf("sent alerts for %v, want api and cdn", sent)
+	}
+}
@@ -0,0 +1,20 @@
+package alerting
+
+import (
+	"context"
+	"log"
+)
+
+// LogNotifier writes alerts to the service's log
+type LogNotifier struct{}
+
+// NewLogNotifier creates a new log notifier
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+// Notify logs the alert's summary
+func (n *LogNotifier) Notify(ctx context.Context, alert Alert) error {
+	log.Printf("ALERT: %s", alert.Summary())
+	return nil
+}
@@ -0,0 +1,184 @@
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/backoff"
+	"github.com/openlearnnitj/openlearn-monitoring/internal/config"
+)
+
+// WebhookVersion is the version of the webhook payload, raised whenever a
+// change would break receivers
+const WebhookVersion = 1
+
+// Webhook request headers
+const (
+	// SignatureHeader carries "sha256=" and the hex HMAC-SHA256 of the
+	// request body, keyed with the channel's secret
+	SignatureHeader = "X-OpenLearn-Signature"
+	// EventHeader names the kind of alert, as the payload's event does
+	EventHeader = "X-OpenLearn-Event"
+)
+
+// Webhook events
+const (
+	EventStatusChanged = "component.status_changed"
+	EventRecovered     = "component.recovered"
+)
+
+// WebhookPayload is the JSON body POSTed for each alert
+type WebhookPayload struct {
+	Version        int        `json:"version"`
+	Event          string     `json:"event"`
+	Component      string     `json:"component"`
+	PreviousStatus string     `json:"previousStatus,omitempty"` // omitted for a new component
+	Status         string     `json:"status"`
+	Message        string     `json:"message,omitempty"`
+	ResponseTimeMs float64    `json:"responseTimeMs"`
+	Timestamp      time.Time  `json:"timestamp"`
+	OutageStart    *time.Time `json:"outageStart,omitempty"`
+	// OutageDurationSeconds is how long the outage ended by a recovery lasted
+	OutageDurationSeconds float64 `json:"outageDurationSeconds,omitempty"`
+	IncidentURL           string  `json:"incidentUrl,omitempty"` // the status page, if configured
+}
+
+// WebhookNotifier POSTs alerts to a URL as signed JSON
+type WebhookNotifier struct {
+	url         string
+	secret      string
+	retries     int
+	backoff     time.Duration
+	incidentURL string
+	client      *http.Client
+}
+
+// NewWebhookNotifier creates a notifier for a webhook channel. Payloads
+// link to the status page at incidentURL unless it's empty.
+func NewWebhookNotifier(channel config.AlertChannel, incidentURL string) *WebhookNotifier {
+	retries := 0
+	if channel.Retries != nil {
+		retries = *channel.Retries
+	}
+	return &WebhookNotifier{
+		url:         channel.URL,
+		secret:      channel.Secret,
+		retries:     retries,
+		backoff:     time.Duration(channel.RetryBackoff),
+		incidentURL: incidentURL,
+		client:      &http.Client{Timeout: time.Duration(channel.Timeout)},
+	}
+}
+
+// Notify POSTs the alert, retrying with backoff until the webhook answers
+// with a 2xx status, the retries run out or ctx is done
+func (n *WebhookNotifier) Notify(ctx context.Context, alert Alert) error {
+	payload := n.payload(alert)
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	delay := n.backoff
+	attempt := 1
+	for ; ; attempt++ {
+		err = n.deliver(ctx, payload.Event, body)
+		if err == nil {
+			return nil
+		}
+		if attempt > n.retries {
+			break
+		}
+
+		log.Printf("Delivery %d of %d to webhook %s failed: %v; retrying in %s", attempt, n.retries+1, n.host(), err, delay)
+		if !backoff.Wait(ctx, delay) {
+			break
+		}
+		delay *= 2
+	}
+
+	if attempt == 1 {
+		return fmt.Errorf("webhook %s: %w", n.host(), err)
+	}
+	return fmt.Errorf("webhook %s: %d deliveries failed, last: %w", n.host(), attempt, err)
+}
+
+// payload describes an alert in the webhook's JSON schema
+func (n *WebhookNotifier) payload(alert Alert) WebhookPayload {
+	event := EventStatusChanged
+	if alert.Recovered() {
+		event = EventRecovered
+	}
+	payload := WebhookPayload{
+		Version:               WebhookVersion,
+		Event:                 event,
+		Component:             alert.Component,
+		PreviousStatus:        alert.PreviousStatus,
+		Status:                alert.Status,
+		Message:               alert.Message,
+		ResponseTimeMs:        alert.ResponseTimeMs,
+		Timestamp:             alert.Timestamp,
+		OutageDurationSeconds: alert.OutageDuration.Seconds(),
+		IncidentURL:           n.incidentURL,
+	}
+	if !alert.OutageStart.IsZero() {
+		payload.OutageStart = &alert.OutageStart
+	}
+	return payload
+}
+
+// deliver makes one signed POST of the payload
+func (n *WebhookNotifier) deliver(ctx context.Context, event string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "openlearn-monitoring")
+	req.Header.Set(EventHeader, event)
+	req.Header.Set(SignatureHeader, Sign(n.secret, body))
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		// The error would quote the whole URL; host() already names it
+		if urlErr, ok := err.(*url.Error); ok {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// host names the webhook in logs and errors without the rest of its URL,
+// which often embeds a token
+func (n *WebhookNotifier) host() string {
+	if parsed, err := url.Parse(n.url); err == nil {
+		return parsed.Host
+	}
+	return "(invalid url)"
+}
+
+// Sign returns the signature header value of a payload: "sha256=" and the
+// hex HMAC-SHA256 of body keyed with secret. Receivers should compute it
+// over the raw request body and compare with hmac.Equal.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
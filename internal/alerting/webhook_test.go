@@ -0,0 +1,122 @@
+package alerting
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/config"
+)
+
+// webhookChannel configures a webhook channel for a test server
+func webhookChannel(url string, retries int) config.AlertChannel {
+	return config.AlertChannel{
+		Type:         config.ChannelWebhook,
+		URL:          url,
+		Secret:       "s3cret",
+		Retries:      &retries,
+		RetryBackoff: config.Duration(10 * time.Millisecond),
+		Timeout:      config.Duration(time.Second),
+	}
+}
+
+func TestWebhookNotify(t *testing.T) {
+	var (
+		got       WebhookPayload
+		signature string
+		event     string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		signature = r.Header.Get(SignatureHeader)
+		event = r.Header.Get(EventHeader)
+		if !hmac.Equal([]byte(signature), []byte(Sign("s3cret", body))) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.Unmarshal(body, &got)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	notifier := NewWebhookNotifier(webhookChannel(server.URL+"/hooks/status", 0), "https://status.openlearn.org.in")
+	alert := Alert{
+		Component:      "api/Database",
+		PreviousStatus: "DOWN",
+		Status:         "OPERATIONAL",
+		ResponseTimeMs: 42,
+		Timestamp:      testNow,
+		OutageStart:    testNow.Add(-5 * time.Minute),
+		OutageDuration: 5 * time.Minute,
+	}
+	if err := notifier.Notify(context.Background(), alert); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	if !strings.HasPrefix(signature, "sha256=") || event != EventRecovered {
+		t.Errorf("headers: signature %q, event %q", signature, event)
+	}
+	if got.Version != WebhookVersion || got.Event != EventRecovered || got.Component != "api/Database" ||
+		got.PreviousStatus != "DOWN" || got.Status != "OPERATIONAL" || got.ResponseTimeMs != 42 ||
+		!got.Timestamp.Equal(testNow) || got.OutageStart == nil || got.OutageDurationSeconds != 300 ||
+		got.IncidentURL != "https://status.openlearn.org.in" {
+		t.Errorf("payload = %+v", got)
+	}
+}
+
+func TestWebhookRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		failures     int32 // requests answered with 503 before succeeding
+		retries      int
+		wantRequests int32
+		wantErr      string
+	}{
+		{name: "succeeds after retries", failures: 2, retries: 3, wantRequests: 3},
+		{name: "retries run out", failures: 10, retries: 2, wantRequests: 3, wantErr: "3 deliveries failed, last: unexpected status code: 503"},
+		{name: "no retries", failures: 1, retries: 0, wantRequests: 1, wantErr: "unexpected status code: 503"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if requests.Add(1) <= tt.failures {
+					w.WriteHeader(http.StatusServiceUnavailable)
+				}
+			}))
+			t.Cleanup(server.Close)
+
+			notifier := NewWebhookNotifier(webhookChannel(server.URL, tt.retries), "")
+			err := notifier.Notify(context.Background(), Alert{Component: "api", Status: "DOWN", Timestamp: testNow})
+
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("Notify() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("Notify() error = %v, want it to mention %q", err, tt.wantErr)
+			}
+			if requests.Load() != tt.wantRequests {
+				t.Errorf("got %d requests, want %d", requests.Load(), tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestWebhookErrorHidesURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	notifier := NewWebhookNotifier(webhookChannel(server.URL+"/hooks/T0KEN", 0), "")
+	err := notifier.Notify(context.Background(), Alert{Component: "api", Status: "DOWN", Timestamp: testNow})
+	if err == nil || strings.Contains(err.Error(), "T0KEN") {
+		t.Errorf("Notify() error = %v, want a failure naming only the host", err)
+	}
+}
@@ -0,0 +1,25 @@
+// Package backoff holds the waiting shared by the code that retries probes
+// and alert deliveries.
+package backoff
+
+import (
+	"context"
+	"time"
+)
+
+// Wait sleeps for d, returning false early if ctx is done first or its
+// deadline is too close to wait out
+func Wait(ctx context.Context, d time.Duration) bool {
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < d {
+		return false
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,37 @@
+package backoff
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWait(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	short, cancelShort := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancelShort()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		d    time.Duration
+		want bool
+	}{
+		{"waits out d", context.Background(), 5 * time.Millisecond, true},
+		{"context done", cancelled, time.Second, false},
+		{"deadline too close", short, time.Second, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Now()
+			if got := Wait(tt.ctx, tt.d); got != tt.want {
+				t.Errorf("Wait() = %v, want %v", got, tt.want)
+			}
+			if elapsed := time.Since(start); !tt.want && elapsed >= tt.d {
+				t.Errorf("Wait() gave up after %s, not early", elapsed)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+)
+
+// Supported alert channel types
+const (
+	// ChannelLog writes alerts to the service's log
+	ChannelLog = "log"
+	// ChannelWebhook POSTs a signed JSON payload to a URL
+	ChannelWebhook = "webhook"
+)
+
+// Webhook channel defaults
+const (
+	defaultWebhookRetries = 3
+	defaultWebhookBackoff = time.Second
+	defaultWebhookTimeout = 10 * time.Second
+)
+
+// AlertChannel is somewhere alerts about components changing status are sent
+type AlertChannel struct {
+	Type string `json:"type" yaml:"type"`
+
+	// Webhook settings. Secret keys the HMAC-SHA256 signature of each
+	// payload. A delivery answered with anything but a 2xx status is
+	// retried up to Retries times, waiting RetryBackoff before the first
+	// retry and doubling it after each one.
+	URL          string   `json:"url" yaml:"url"`
+	Secret       string   `json:"secret" yaml:"secret"`
+	Retries      *int     `json:"retries" yaml:"retries"`
+	RetryBackoff Duration `json:"retryBackoff" yaml:"retryBackoff"`
+	Timeout      Duration `json:"timeout" yaml:"timeout"`
+}
+
+// loadAlertChannels reads the alert channels from ALERT_CHANNELS (a JSON
+// array), which replaces the channels of the config file, and validates them
+// along with the status page URL alerts link to
+func loadAlertChannels(cfg *Config) error {
+	if value := os.Getenv("ALERT_CHANNELS"); value != "" {
+		var channels []AlertChannel
+		if err := json.Unmarshal([]byte(value), &channels); err != nil {
+			return fmt.Errorf("ALERT_CHANNELS is not a valid JSON array of channels: %w", err)
+		}
+		cfg.AlertChannels = channels
+	}
+
+	for i := range cfg.AlertChannels {
+		channel := &cfg.AlertChannels[i]
+		channel.applyDefaults()
+
+		if err := channel.validate(); err != nil {
+			return fmt.Errorf("alert channel %d: %w", i+1, err)
+		}
+	}
+
+	if cfg.StatusPageURL != "" && !isHTTPURL(cfg.StatusPageURL) {
+		return fmt.Errorf("status page URL must be an http or https URL, got %q", cfg.StatusPageURL)
+	}
+
+	return nil
+}
+
+// applyDefaults fills in the optional settings of the channel's type
+func (c *AlertChannel) applyDefaults() {
+	if c.Type != ChannelWebhook {
+		return
+	}
+	if c.Retries == nil {
+		retries := defaultWebhookRetries
+		c.Retries = &retries
+	}
+	if c.RetryBackoff == 0 {
+		c.RetryBackoff = Duration(defaultWebhookBackoff)
+	}
+	if c.Timeout == 0 {
+		c.Timeout = Duration(defaultWebhookTimeout)
+	}
+}
+
+// validate checks the settings required by the channel's type
+func (c *AlertChannel) validate() error {
+	switch c.Type {
+	case ChannelLog:
+		return nil
+	case ChannelWebhook:
+		if !isHTTPURL(c.URL) {
+			return fmt.Errorf("url must be an http or https URL")
+		}
+		if c.Secret == "" {
+			return fmt.Errorf("secret is required to sign webhook payloads")
+		}
+		if *c.Retries < 0 {
+			return fmt.Errorf("retries can't be negative")
+		}
+		if c.RetryBackoff < 0 || c.Timeout < 0 {
+			return fmt.Errorf("retryBackoff and timeout must be positive")
+		}
+		return nil
+	case "":
+		return fmt.Errorf("type is required")
+	default:
+		return fmt.Errorf("unsupported channel type %q", c.Type)
+	}
+}
+
+// isHTTPURL reports whether value is an absolute http or https URL
+func isHTTPURL(value string) bool {
+	parsed, err := url.Parse(value)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
@@ -3,44 +3,186 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"time"
 )
 
+// Supported storage backends
+const (
+	BackendDynamoDB = "dynamodb"
+	BackendFile     = "file"
+)
+
+// defaultStorageFilePath is used by the file backend when STORAGE_FILE_PATH is unset
+const defaultStorageFilePath = "data/checks.jsonl"
+
+// defaultRetentionDays covers the 90-day status history shown on the status page
+const defaultRetentionDays = 90
+
+// defaultSchedulerConcurrency is how many checks the server's scheduler runs at once
+const defaultSchedulerConcurrency = 4
+
+// defaultLeaseTTL is how long a leading replica's lease lasts without renewal
+const defaultLeaseTTL = 15 * time.Second
+
 // Config holds all configuration values for the monitoring service
 type Config struct {
 	MonitoringAPIURL    string
 	MonitoringAPISecret string
+	Targets             []Target
+	StorageBackend      string
+	StorageFilePath     string
 	DynamoDBTableName   string
 	AWSRegion           string
+	RetentionDays       int
 	Port                string
+
+	// SchedulerConcurrency bounds the checks run at once by the scheduler
+	// of cmd/server
+	SchedulerConcurrency int
+
+	// LeaderElection makes replicas of cmd/server sharing the storage
+	// backend elect one leader to run the scheduler, holding a lease that
+	// expires after LeaseTTL unless renewed
+	LeaderElection bool
+	LeaseTTL       time.Duration
+
+	// AlertChannels receive an alert whenever a component changes status.
+	// Alerts link to the status page at StatusPageURL, if it's set.
+	AlertChannels []AlertChannel
+	StatusPageURL string
 }
 
-// LoadConfig loads configuration from environment variables
+// LoadConfig loads configuration from the file named by CONFIG_FILE, if any,
+// and from environment variables
 func LoadConfig() (*Config, error) {
-	cfg := &Config{}
+	return Load(os.Getenv("CONFIG_FILE"))
+}
 
-	var err error
-	if cfg.MonitoringAPIURL, err = getEnvVar("MONITORING_API_URL"); err != nil {
+// Load reads the optional config file at path, then lets environment
+// variables override individual fields and validates the result
+func Load(path string) (*Config, error) {
+	cfg := &Config{
+		StorageBackend:       BackendDynamoDB,
+		RetentionDays:        defaultRetentionDays,
+		SchedulerConcurrency: defaultSchedulerConcurrency,
+		LeaseTTL:             defaultLeaseTTL,
+	}
+
+	if path != "" {
+		if err := loadFile(path, cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := loadTargets(cfg); err != nil {
 		return nil, err
 	}
 
-	if cfg.MonitoringAPISecret, err = getEnvVar("MONITORING_API_SECRET"); err != nil {
+	if err := loadEnv(cfg); err != nil {
 		return nil, err
 	}
 
-	if cfg.DynamoDBTableName, err = getEnvVar("DYNAMODB_TABLE_NAME"); err != nil {
+	if err := loadAlertChannels(cfg); err != nil {
 		return nil, err
 	}
 
-	if cfg.AWSRegion, err = getEnvVar("AWS_REGION"); err != nil {
+	if err := cfg.validateStorage(); err != nil {
 		return nil, err
 	}
 
-	// Port is optional, default will be used if not set
-	cfg.Port = os.Getenv("PORT")
+	if cfg.SchedulerConcurrency < 1 {
+		return nil, fmt.Errorf("scheduler concurrency must be at least 1, got %d", cfg.SchedulerConcurrency)
+	}
+	if cfg.LeaseTTL < time.Second {
+		return nil, fmt.Errorf("lease TTL must be at least 1s, got %s", cfg.LeaseTTL)
+	}
 
 	return cfg, nil
 }
 
+// loadEnv applies the storage and server environment variables on top of
+// the values read from the config file
+func loadEnv(cfg *Config) error {
+	overrides := map[string]*string{
+		"STORAGE_BACKEND":     &cfg.StorageBackend,
+		"STORAGE_FILE_PATH":   &cfg.StorageFilePath,
+		"DYNAMODB_TABLE_NAME": &cfg.DynamoDBTableName,
+		"AWS_REGION":          &cfg.AWSRegion,
+		"PORT":                &cfg.Port,
+		"STATUS_PAGE_URL":     &cfg.StatusPageURL,
+	}
+	for key, field := range overrides {
+		if value := os.Getenv(key); value != "" {
+			*field = value
+		}
+	}
+
+	// Retention is optional; 0 keeps raw checks forever
+	if value := os.Getenv("RETENTION_DAYS"); value != "" {
+		days, err := strconv.Atoi(value)
+		if err != nil || days < 0 {
+			return fmt.Errorf("RETENTION_DAYS must be a non-negative number of days, got %q", value)
+		}
+		cfg.RetentionDays = days
+	}
+
+	if value := os.Getenv("SCHEDULER_CONCURRENCY"); value != "" {
+		concurrency, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("SCHEDULER_CONCURRENCY must be a number of checks, got %q", value)
+		}
+		cfg.SchedulerConcurrency = concurrency
+	}
+
+	if value := os.Getenv("LEADER_ELECTION"); value != "" {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("LEADER_ELECTION must be true or false, got %q", value)
+		}
+		cfg.LeaderElection = enabled
+	}
+
+	if value := os.Getenv("LEASE_TTL"); value != "" {
+		ttl, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("LEASE_TTL must be a duration such as 15s, got %q", value)
+		}
+		cfg.LeaseTTL = ttl
+	}
+
+	return nil
+}
+
+// validateStorage checks the settings required by the selected backend
+func (c *Config) validateStorage() error {
+	switch c.StorageBackend {
+	case BackendDynamoDB:
+		if err := requireSetting(c.DynamoDBTableName, "DYNAMODB_TABLE_NAME", "storage.dynamodbTableName"); err != nil {
+			return err
+		}
+		if err := requireSetting(c.AWSRegion, "AWS_REGION", "storage.awsRegion"); err != nil {
+			return err
+		}
+	case BackendFile:
+		if c.StorageFilePath == "" {
+			c.StorageFilePath = defaultStorageFilePath
+		}
+	default:
+		return fmt.Errorf("unsupported storage backend %q", c.StorageBackend)
+	}
+
+	if c.RetentionDays < 0 {
+		return fmt.Errorf("retention must be a non-negative number of days, got %d", c.RetentionDays)
+	}
+	return nil
+}
+
+// Retention returns how long raw check results are kept, or 0 to keep them forever
+func (c *Config) Retention() time.Duration {
+	return time.Duration(c.RetentionDays) * 24 * time.Hour
+}
+
 // getEnvVar retrieves an environment variable or returns an error if not set
 func getEnvVar(key string) (string, error) {
 	value := os.Getenv(key)
@@ -49,3 +191,11 @@ func getEnvVar(key string) (string, error) {
 	}
 	return value, nil
 }
+
+// requireSetting returns an error naming both places a missing value can be set
+func requireSetting(value, envKey, fileKey string) error {
+	if value == "" {
+		return fmt.Errorf("%s is not set: set the %s environment variable or %s in the config file", fileKey, envKey, fileKey)
+	}
+	return nil
+}
@@ -0,0 +1,464 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// setEnv sets the variables for a test, clearing the ones LoadConfig reads first
+func setEnv(t *testing.T, env map[string]string) {
+	t.Helper()
+	for _, key := range []string{
+		"MONITORING_API_URL", "MONITORING_API_SECRET", "MONITORING_TARGETS",
+		"STORAGE_BACKEND", "STORAGE_FILE_PATH", "DYNAMODB_TABLE_NAME", "AWS_REGION",
+		"RETENTION_DAYS", "PORT", "CONFIG_FILE", "SCHEDULER_CONCURRENCY",
+		"LEADER_ELECTION", "LEASE_TTL", "ALERT_CHANNELS", "STATUS_PAGE_URL",
+	} {
+		t.Setenv(key, "")
+	}
+	for key, value := range env {
+		t.Setenv(key, value)
+	}
+}
+
+func TestLoadConfigLegacyTarget(t *testing.T) {
+	setEnv(t, map[string]string{
+		"MONITORING_API_URL":    "https://api.example.com/health",
+		"MONITORING_API_SECRET": "secret",
+		"DYNAMODB_TABLE_NAME":   "Status",
+		"AWS_REGION":            "ap-south-1",
+	})
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	want := Target{
+		Kind:         KindHealth,
+		URL:          "https://api.example.com/health",
+		AuthHeader:   "X-API-Secret",
+		AuthValue:    "secret",
+		Timeout:      Duration(30 * time.Second),
+		Format:       FormatComponents,
+		Interval:     Duration(time.Minute),
+		ConfirmAfter: 1,
+	}
+	if len(cfg.Targets) != 1 || !reflect.DeepEqual(cfg.Targets[0], want) {
+		t.Errorf("Targets = %+v, want [%+v]", cfg.Targets, want)
+	}
+	if cfg.StorageBackend != BackendDynamoDB || cfg.RetentionDays != 90 {
+		t.Errorf("defaults not applied: backend %q, retention %d", cfg.StorageBackend, cfg.RetentionDays)
+	}
+}
+
+func TestLoadConfigTargets(t *testing.T) {
+	setEnv(t, map[string]string{
+		"MONITORING_TARGETS": `[
+			{"name": "api", "url": "https://api.example.com/health", "authValue": "s1", "retries": 2, "confirmAfter": 3},
+			{"name": "cdn", "url": "https://cdn.example.com/health", "authHeader": "Authorization", "authValue": "Bearer t", "timeout": "5s", "format": "overall"}
+		]`,
+		"STORAGE_BACKEND": "file",
+	})
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if len(cfg.Targets) != 2 {
+		t.Fatalf("got %d targets, want 2", len(cfg.Targets))
+	}
+
+	api := cfg.Targets[0]
+	if api.Retries != 2 || api.RetryBackoff != Duration(time.Second) || api.ConfirmAfter != 3 {
+		t.Errorf("api retries = %d, backoff %v, confirmAfter %d", api.Retries, api.RetryBackoff, api.ConfirmAfter)
+	}
+
+	cdn := cfg.Targets[1]
+	if cdn.AuthHeader != "Authorization" || cdn.Timeout != Duration(5*time.Second) || cdn.Format != FormatOverall {
+		t.Errorf("cdn target = %+v", cdn)
+	}
+	if cdn.Retries != 0 || cdn.RetryBackoff != 0 || cdn.ConfirmAfter != 1 {
+		t.Errorf("cdn retries = %d, backoff %v, confirmAfter %d", cdn.Retries, cdn.RetryBackoff, cdn.ConfirmAfter)
+	}
+	if cfg.StorageFilePath != defaultStorageFilePath {
+		t.Errorf("StorageFilePath = %q, want default", cfg.StorageFilePath)
+	}
+}
+
+func TestLoadConfigHTTPTarget(t *testing.T) {
+	setEnv(t, map[string]string{
+		"MONITORING_TARGETS": `[{"name": "site", "kind": "http", "url": "https://openlearn.org.in", "method": "head", "expectStatus": [200, 204]}]`,
+		"STORAGE_BACKEND":    "file",
+	})
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	site := cfg.Targets[0]
+	if site.Method != "HEAD" || site.Redirects != RedirectsFollow {
+		t.Errorf("defaults not applied: method %q, redirects %q", site.Method, site.Redirects)
+	}
+	if !reflect.DeepEqual(site.ExpectStatus, []int{200, 204}) {
+		t.Errorf("ExpectStatus = %v", site.ExpectStatus)
+	}
+}
+
+func TestLoadConfigTLSTarget(t *testing.T) {
+	setEnv(t, map[string]string{
+		"MONITORING_TARGETS": `[
+			{"name": "cert", "kind": "tls", "address": "openlearn.org.in:443"},
+			{"name": "site", "kind": "http", "url": "https://openlearn.org.in", "checkCertificate": true, "certWarningDays": 30}
+		]`,
+		"STORAGE_BACKEND": "file",
+	})
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	if cert := cfg.Targets[0]; cert.CertWarningDays != 14 || cert.CertCriticalDays != 7 {
+		t.Errorf("default thresholds = %d/%d, want 14/7", cert.CertWarningDays, cert.CertCriticalDays)
+	}
+	if site := cfg.Targets[1]; !site.ChecksCertificate() || site.CertWarningDays != 30 || site.CertCriticalDays != 7 {
+		t.Errorf("site target = %+v", site)
+	}
+}
+
+func TestLoadConfigDNSTarget(t *testing.T) {
+	setEnv(t, map[string]string{
+		"MONITORING_TARGETS": `[
+			{"name": "dns", "kind": "dns", "hostname": "openlearn.org.in", "resolver": "1.1.1.1"},
+			{"name": "mx", "kind": "dns", "hostname": "openlearn.org.in", "recordType": "mx", "resolver": "[2606:4700:4700::1111]:5353"}
+		]`,
+		"STORAGE_BACKEND": "file",
+	})
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	if dns := cfg.Targets[0]; dns.RecordType != "A" || dns.Resolver != "1.1.1.1:53" {
+		t.Errorf("dns target = %q via %q, want A via 1.1.1.1:53", dns.RecordType, dns.Resolver)
+	}
+	if mx := cfg.Targets[1]; mx.RecordType != "MX" || mx.Resolver != "[2606:4700:4700::1111]:5353" {
+		t.Errorf("mx target = %q via %q", mx.RecordType, mx.Resolver)
+	}
+}
+
+func TestLoadConfigSyntheticTarget(t *testing.T) {
+	setEnv(t, map[string]string{
+		"MONITORING_TARGETS": `[{"name": "journey", "kind": "synthetic", "steps": [
+			{"name": "log in", "method": "post", "url": "https://api.example.com/login", "extract": {"token": "$.token"}, "assertions": [{"expr": "$.token"}]},
+			{"url": "https://api.example.com/courses", "headers": {"Authorization": "Bearer ${token}"}}
+		]}]`,
+		"STORAGE_BACKEND": "file",
+	})
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	steps := cfg.Targets[0].Steps
+	if steps[0].Method != "POST" || steps[0].Assertions[0].Severity != "DOWN" {
+		t.Errorf("defaults not applied to step 1: %+v", steps[0])
+	}
+	if steps[1].Name != "step 2" || steps[1].Method != "GET" {
+		t.Errorf("defaults not applied to step 2: %+v", steps[1])
+	}
+}
+
+func TestExpand(t *testing.T) {
+	got := Expand("/users/${userID}/courses?token=${token}&q=$literal&missing=${missing}", map[string]string{"userID": "42", "token": "abc"})
+	if want := "/users/42/courses?token=abc&q=$literal&missing="; got != want {
+		t.Errorf("Expand() = %q, want %q", got, want)
+	}
+}
+
+func TestLoadConfigAssertions(t *testing.T) {
+	setEnv(t, map[string]string{
+		"MONITORING_TARGETS": `[{"name": "api", "url": "https://api.example.com/health", "assertions": [
+			{"expr": "$.db.replicationLag < 5", "severity": "DEGRADED", "component": "Database"},
+			{"expr": "$.status == \"UP\""}
+		]}]`,
+		"STORAGE_BACKEND": "file",
+	})
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	want := []Assertion{
+		{Expr: "$.db.replicationLag < 5", Severity: "DEGRADED", Component: "Database"},
+		{Expr: `$.status == "UP"`, Severity: "DOWN"},
+	}
+	if got := cfg.Targets[0].Assertions; !reflect.DeepEqual(got, want) {
+		t.Errorf("Assertions = %+v, want %+v", got, want)
+	}
+}
+
+func TestLoadConfigWebhookChannel(t *testing.T) {
+	setEnv(t, map[string]string{
+		"MONITORING_TARGETS": `[{"url": "http://a"}]`,
+		"STORAGE_BACKEND":    "file",
+		"ALERT_CHANNELS": `[
+			{"type": "webhook", "url": "https://hooks.example.com/status", "secret": "s1"},
+			{"type": "webhook", "url": "https://ops.example.com/status", "secret": "s2", "retries": 0, "timeout": "2s"}
+		]`,
+		"STATUS_PAGE_URL": "https://status.openlearn.org.in",
+	})
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	if len(cfg.AlertChannels) != 2 {
+		t.Fatalf("got %d alert channels, want 2", len(cfg.AlertChannels))
+	}
+	if hooks := cfg.AlertChannels[0]; *hooks.Retries != 3 || hooks.RetryBackoff != Duration(time.Second) || hooks.Timeout != Duration(10*time.Second) {
+		t.Errorf("defaults not applied to webhook: %+v", hooks)
+	}
+	if ops := cfg.AlertChannels[1]; *ops.Retries != 0 || ops.Timeout != Duration(2*time.Second) {
+		t.Errorf("webhook settings not kept: %+v", ops)
+	}
+	if cfg.StatusPageURL != "https://status.openlearn.org.in" {
+		t.Errorf("StatusPageURL = %q", cfg.StatusPageURL)
+	}
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr string
+	}{
+		{
+			name:    "missing legacy url",
+			env:     map[string]string{"STORAGE_BACKEND": "file"},
+			wantErr: "MONITORING_API_URL",
+		},
+		{
+			name:    "invalid targets json",
+			env:     map[string]string{"MONITORING_TARGETS": `{"name": "api"}`, "STORAGE_BACKEND": "file"},
+			wantErr: "not a valid JSON array",
+		},
+		{
+			name:    "unnamed target among several",
+			env:     map[string]string{"MONITORING_TARGETS": `[{"url": "http://a"}, {"name": "b", "url": "http://b"}]`, "STORAGE_BACKEND": "file"},
+			wantErr: "target 1: name is required",
+		},
+		{
+			name:    "duplicate names",
+			env:     map[string]string{"MONITORING_TARGETS": `[{"name": "a", "url": "http://a"}, {"name": "a", "url": "http://b"}]`, "STORAGE_BACKEND": "file"},
+			wantErr: `duplicate target name "a"`,
+		},
+		{
+			name:    "unknown format",
+			env:     map[string]string{"MONITORING_TARGETS": `[{"name": "a", "url": "http://a", "format": "xml"}]`, "STORAGE_BACKEND": "file"},
+			wantErr: `unsupported format "xml"`,
+		},
+		{
+			name:    "bad timeout",
+			env:     map[string]string{"MONITORING_TARGETS": `[{"name": "a", "url": "http://a", "timeout": "soon"}]`, "STORAGE_BACKEND": "file"},
+			wantErr: "not a valid JSON array",
+		},
+		{
+			name:    "negative retries",
+			env:     map[string]string{"MONITORING_TARGETS": `[{"name": "a", "url": "http://a", "retries": -1}]`, "STORAGE_BACKEND": "file"},
+			wantErr: "target 1: retries can't be negative",
+		},
+		{
+			name:    "negative confirmAfter",
+			env:     map[string]string{"MONITORING_TARGETS": `[{"name": "a", "url": "http://a", "confirmAfter": -2}]`, "STORAGE_BACKEND": "file"},
+			wantErr: "target 1: confirmAfter can't be negative",
+		},
+		{
+			name:    "unknown kind",
+			env:     map[string]string{"MONITORING_TARGETS": `[{"name": "a", "kind": "ftp", "url": "ftp://a"}]`, "STORAGE_BACKEND": "file"},
+			wantErr: `unsupported kind "ftp"`,
+		},
+		{
+			name:    "unnamed http target",
+			env:     map[string]string{"MONITORING_TARGETS": `[{"kind": "http", "url": "http://a"}]`, "STORAGE_BACKEND": "file"},
+			wantErr: "name is required",
+		},
+		{
+			name:    "invalid body regex",
+			env:     map[string]string{"MONITORING_TARGETS": `[{"name": "a", "kind": "http", "url": "http://a", "bodyMatches": "("}]`, "STORAGE_BACKEND": "file"},
+			wantErr: "bodyMatches",
+		},
+		{
+			name:    "invalid expected status",
+			env:     map[string]string{"MONITORING_TARGETS": `[{"name": "a", "kind": "http", "url": "http://a", "expectStatus": [2000]}]`, "STORAGE_BACKEND": "file"},
+			wantErr: "expectStatus 2000",
+		},
+		{
+			name:    "unknown redirect policy",
+			env:     map[string]string{"MONITORING_TARGETS": `[{"name": "a", "kind": "http", "url": "http://a", "redirects": "sometimes"}]`, "STORAGE_BACKEND": "file"},
+			wantErr: `unsupported redirects policy "sometimes"`,
+		},
+		{
+			name:    "tcp target without port",
+			env:     map[string]string{"MONITORING_TARGETS": `[{"name": "db", "kind": "tcp", "address": "db.internal"}]`, "STORAGE_BACKEND": "file"},
+			wantErr: "address must be host:port",
+		},
+		{
+			name:    "assertions on tcp target",
+			env:     map[string]string{"MONITORING_TARGETS": `[{"name": "db", "kind": "tcp", "address": "db.internal:5432", "assertions": [{"expr": "$.ok"}]}]`, "STORAGE_BACKEND": "file"},
+			wantErr: `assertions are not supported for "tcp" targets`,
+		},
+		{
+			name:    "tls thresholds inverted",
+			env:     map[string]string{"MONITORING_TARGETS": `[{"name": "cert", "kind": "tls", "address": "openlearn.org.in:443", "certWarningDays": 3, "certCriticalDays": 10}]`, "STORAGE_BACKEND": "file"},
+			wantErr: "certWarningDays (3) must be at least certCriticalDays (10)",
+		},
+		{
+			name:    "certificate check on plain http",
+			env:     map[string]string{"MONITORING_TARGETS": `[{"name": "site", "kind": "http", "url": "http://openlearn.org.in", "checkCertificate": true}]`, "STORAGE_BACKEND": "file"},
+			wantErr: "checkCertificate requires an https url",
+		},
+		{
+			name:    "dns target without hostname",
+			env:     map[string]string{"MONITORING_TARGETS": `[{"name": "dns", "kind": "dns"}]`, "STORAGE_BACKEND": "file"},
+			wantErr: "hostname is required",
+		},
+		{
+			name:    "unsupported record type",
+			env:     map[string]string{"MONITORING_TARGETS": `[{"name": "dns", "kind": "dns", "hostname": "openlearn.org.in", "recordType": "SRV"}]`, "STORAGE_BACKEND": "file"},
+			wantErr: `unsupported recordType "SRV"`,
+		},
+		{
+			name:    "expected A value is not an IP",
+			env:     map[string]string{"MONITORING_TARGETS": `[{"name": "dns", "kind": "dns", "hostname": "openlearn.org.in", "expect": ["cdn.example.com"]}]`, "STORAGE_BACKEND": "file"},
+			wantErr: "not an IP address",
+		},
+		{
+			name:    "grpc target without port",
+			env:     map[string]string{"MONITORING_TARGETS": `[{"name": "payments", "kind": "grpc", "address": "payments.internal"}]`, "STORAGE_BACKEND": "file"},
+			wantErr: "address must be host:port",
+		},
+		{
+			name:    "grpc skip verify without tls",
+			env:     map[string]string{"MONITORING_TARGETS": `[{"name": "payments", "kind": "grpc", "address": "payments.internal:50051", "tlsSkipVerify": true}]`, "STORAGE_BACKEND": "file"},
+			wantErr: "tlsSkipVerify requires tls",
+		},
+		{
+			name:    "synthetic target without steps",
+			env:     map[string]string{"MONITORING_TARGETS": `[{"name": "journey", "kind": "synthetic"}]`, "STORAGE_BACKEND": "file"},
+			wantErr: "steps are required",
+		},
+		{
+			name: "variable used before it is extracted",
+			env: map[string]string{"MONITORING_TARGETS": `[{"name": "journey", "kind": "synthetic", "steps": [
+				{"url": "http://a/courses/${courseID}"},
+				{"url": "http://a/courses", "extract": {"courseID": "$.courses[0].id"}}
+			]}]`, "STORAGE_BACKEND": "file"},
+			wantErr: "step 1 (step 1): ${courseID} is not extracted by an earlier step",
+		},
+		{
+			name:    "invalid extract path",
+			env:     map[string]string{"MONITORING_TARGETS": `[{"name": "journey", "kind": "synthetic", "steps": [{"name": "login", "url": "http://a", "extract": {"token": "token"}}]}]`, "STORAGE_BACKEND": "file"},
+			wantErr: "step 1 (login): extract token",
+		},
+		{
+			name:    "invalid assertion",
+			env:     map[string]string{"MONITORING_TARGETS": `[{"name": "a", "url": "http://a", "assertions": [{"expr": "$.lag < five"}]}]`, "STORAGE_BACKEND": "file"},
+			wantErr: "assertion 1: value",
+		},
+		{
+			name:    "invalid assertion severity",
+			env:     map[string]string{"MONITORING_TARGETS": `[{"name": "a", "url": "http://a", "assertions": [{"expr": "$.ok", "severity": "BROKEN"}]}]`, "STORAGE_BACKEND": "file"},
+			wantErr: "severity must be DEGRADED or DOWN",
+		},
+		{
+			name:    "assertion component on single component target",
+			env:     map[string]string{"MONITORING_TARGETS": `[{"name": "a", "kind": "http", "url": "http://a", "assertions": [{"expr": "$.ok", "component": "db"}]}]`, "STORAGE_BACKEND": "file"},
+			wantErr: "component only applies",
+		},
+		{
+			name:    "unknown storage backend",
+			env:     map[string]string{"MONITORING_TARGETS": `[{"url": "http://a"}]`, "STORAGE_BACKEND": "postgres"},
+			wantErr: `unsupported storage backend "postgres"`,
+		},
+		{
+			name:    "dynamodb without table",
+			env:     map[string]string{"MONITORING_TARGETS": `[{"url": "http://a"}]`},
+			wantErr: "DYNAMODB_TABLE_NAME",
+		},
+		{
+			name:    "negative retention",
+			env:     map[string]string{"MONITORING_TARGETS": `[{"url": "http://a"}]`, "STORAGE_BACKEND": "file", "RETENTION_DAYS": "-1"},
+			wantErr: "RETENTION_DAYS",
+		},
+		{
+			name:    "no scheduler concurrency",
+			env:     map[string]string{"MONITORING_TARGETS": `[{"url": "http://a"}]`, "STORAGE_BACKEND": "file", "SCHEDULER_CONCURRENCY": "0"},
+			wantErr: "scheduler concurrency must be at least 1",
+		},
+		{
+			name:    "invalid leader election",
+			env:     map[string]string{"MONITORING_TARGETS": `[{"url": "http://a"}]`, "STORAGE_BACKEND": "file", "LEADER_ELECTION": "maybe"},
+			wantErr: "LEADER_ELECTION must be true or false",
+		},
+		{
+			name:    "short lease",
+			env:     map[string]string{"MONITORING_TARGETS": `[{"url": "http://a"}]`, "STORAGE_BACKEND": "file", "LEASE_TTL": "100ms"},
+			wantErr: "lease TTL must be at least 1s",
+		},
+		{
+			name:    "negative interval",
+			env:     map[string]string{"MONITORING_TARGETS": `[{"name": "a", "url": "http://a", "interval": "-1m"}]`, "STORAGE_BACKEND": "file"},
+			wantErr: "target 1: interval must be positive",
+		},
+		{
+			name:    "invalid alert channels json",
+			env:     map[string]string{"MONITORING_TARGETS": `[{"url": "http://a"}]`, "STORAGE_BACKEND": "file", "ALERT_CHANNELS": `{"type": "log"}`},
+			wantErr: "ALERT_CHANNELS is not a valid JSON array",
+		},
+		{
+			name:    "unknown alert channel",
+			env:     map[string]string{"MONITORING_TARGETS": `[{"url": "http://a"}]`, "STORAGE_BACKEND": "file", "ALERT_CHANNELS": `[{"type": "log"}, {"type": "pager"}]`},
+			wantErr: `alert channel 2: unsupported channel type "pager"`,
+		},
+		{
+			name:    "webhook without url",
+			env:     map[string]string{"MONITORING_TARGETS": `[{"url": "http://a"}]`, "STORAGE_BACKEND": "file", "ALERT_CHANNELS": `[{"type": "webhook", "secret": "s"}]`},
+			wantErr: "alert channel 1: url must be an http or https URL",
+		},
+		{
+			name:    "unsigned webhook",
+			env:     map[string]string{"MONITORING_TARGETS": `[{"url": "http://a"}]`, "STORAGE_BACKEND": "file", "ALERT_CHANNELS": `[{"type": "webhook", "url": "https://hooks.example.com"}]`},
+			wantErr: "alert channel 1: secret is required",
+		},
+		{
+			name:    "negative webhook retries",
+			env:     map[string]string{"MONITORING_TARGETS": `[{"url": "http://a"}]`, "STORAGE_BACKEND": "file", "ALERT_CHANNELS": `[{"type": "webhook", "url": "https://hooks.example.com", "secret": "s", "retries": -1}]`},
+			wantErr: "alert channel 1: retries can't be negative",
+		},
+		{
+			name:    "relative status page url",
+			env:     map[string]string{"MONITORING_TARGETS": `[{"url": "http://a"}]`, "STORAGE_BACKEND": "file", "STATUS_PAGE_URL": "status.openlearn.org.in"},
+			wantErr: "status page URL must be an http or https URL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setEnv(t, tt.env)
+
+			_, err := LoadConfig()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadConfig() error = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
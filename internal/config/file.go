@@ -0,0 +1,83 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// fileConfig is the schema of the optional config file. JSON files are read
+// with the same decoder, since JSON is a subset of YAML.
+type fileConfig struct {
+	Targets   []Target      `yaml:"targets"`
+	Storage   fileStorage   `yaml:"storage"`
+	Scheduler fileScheduler `yaml:"scheduler"`
+	Alerting  fileAlerting  `yaml:"alerting"`
+	Port      string        `yaml:"port"`
+}
+
+// fileStorage is the storage section of the config file
+type fileStorage struct {
+	Backend           string `yaml:"backend"`
+	FilePath          string `yaml:"filePath"`
+	DynamoDBTableName string `yaml:"dynamodbTableName"`
+	AWSRegion         string `yaml:"awsRegion"`
+	RetentionDays     *int   `yaml:"retentionDays"`
+}
+
+// fileScheduler is the scheduler section of the config file
+type fileScheduler struct {
+	Concurrency    *int     `yaml:"concurrency"`
+	LeaderElection bool     `yaml:"leaderElection"`
+	LeaseTTL       Duration `yaml:"leaseTTL"`
+}
+
+// fileAlerting is the alerting section of the config file
+type fileAlerting struct {
+	Channels      []AlertChannel `yaml:"channels"`
+	StatusPageURL string         `yaml:"statusPageURL"`
+}
+
+// loadFile reads the config file at path into cfg. Unknown fields are
+// rejected so typos don't silently fall back to defaults.
+func loadFile(path string, cfg *Config) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open config file: %w", err)
+	}
+	defer f.Close()
+
+	var file fileConfig
+	decoder := yaml.NewDecoder(f)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+
+	cfg.Targets = file.Targets
+	cfg.Port = file.Port
+	cfg.StorageFilePath = file.Storage.FilePath
+	cfg.DynamoDBTableName = file.Storage.DynamoDBTableName
+	cfg.AWSRegion = file.Storage.AWSRegion
+	if file.Storage.Backend != "" {
+		cfg.StorageBackend = file.Storage.Backend
+	}
+	if file.Storage.RetentionDays != nil {
+		cfg.RetentionDays = *file.Storage.RetentionDays
+	}
+	if file.Scheduler.Concurrency != nil {
+		cfg.SchedulerConcurrency = *file.Scheduler.Concurrency
+	}
+	cfg.LeaderElection = file.Scheduler.LeaderElection
+	if file.Scheduler.LeaseTTL != 0 {
+		cfg.LeaseTTL = time.Duration(file.Scheduler.LeaseTTL)
+	}
+	cfg.AlertChannels = file.Alerting.Channels
+	cfg.StatusPageURL = file.Alerting.StatusPageURL
+
+	return nil
+}
@@ -0,0 +1,190 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeFile writes a config file into a temporary directory
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	return path
+}
+
+const testYAML = `
+targets:
+  - name: api
+    url: https://api.example.com/health
+    authValue: secret
+  - name: cdn
+    url: https://cdn.example.com/health
+    timeout: 5s
+    interval: 30s
+    format: overall
+storage:
+  backend: file
+  filePath: /var/lib/monitoring/checks.jsonl
+  retentionDays: 30
+scheduler:
+  concurrency: 8
+  leaderElection: true
+  leaseTTL: 20s
+alerting:
+  channels:
+    - type: log
+port: "9090"
+`
+
+func TestLoadYAMLFile(t *testing.T) {
+	setEnv(t, nil)
+
+	cfg, err := Load(writeFile(t, "config.yaml", testYAML))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if len(cfg.Targets) != 2 {
+		t.Fatalf("got %d targets, want 2", len(cfg.Targets))
+	}
+	if api := cfg.Targets[0]; api.AuthHeader != defaultAuthHeader || api.Timeout != Duration(defaultTargetTimeout) {
+		t.Errorf("defaults not applied to api target: %+v", api)
+	}
+	if cdn := cfg.Targets[1]; cdn.Timeout != Duration(5*time.Second) || cdn.Interval != Duration(30*time.Second) || cdn.Format != FormatOverall {
+		t.Errorf("cdn target = %+v", cdn)
+	}
+	if cfg.StorageBackend != BackendFile || cfg.StorageFilePath != "/var/lib/monitoring/checks.jsonl" {
+		t.Errorf("storage = %q at %q", cfg.StorageBackend, cfg.StorageFilePath)
+	}
+	if cfg.RetentionDays != 30 || cfg.Port != "9090" {
+		t.Errorf("RetentionDays = %d, Port = %q", cfg.RetentionDays, cfg.Port)
+	}
+	if cfg.SchedulerConcurrency != 8 || !cfg.LeaderElection || cfg.LeaseTTL != 20*time.Second {
+		t.Errorf("scheduler = %d checks, leader election %v, lease TTL %s", cfg.SchedulerConcurrency, cfg.LeaderElection, cfg.LeaseTTL)
+	}
+	if len(cfg.AlertChannels) != 1 || cfg.AlertChannels[0].Type != ChannelLog {
+		t.Errorf("AlertChannels = %+v", cfg.AlertChannels)
+	}
+}
+
+func TestLoadJSONFile(t *testing.T) {
+	setEnv(t, nil)
+
+	path := writeFile(t, "config.json", `{
+		"targets": [{"url": "https://api.example.com/health"}],
+		"storage": {"backend": "dynamodb", "dynamodbTableName": "Status", "awsRegion": "ap-south-1", "retentionDays": 0}
+	}`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(cfg.Targets) != 1 || cfg.Targets[0].URL != "https://api.example.com/health" {
+		t.Errorf("Targets = %+v", cfg.Targets)
+	}
+	if cfg.DynamoDBTableName != "Status" || cfg.AWSRegion != "ap-south-1" {
+		t.Errorf("DynamoDB settings = %q in %q", cfg.DynamoDBTableName, cfg.AWSRegion)
+	}
+	if cfg.RetentionDays != 0 {
+		t.Errorf("RetentionDays = %d, want an explicit 0 to be kept", cfg.RetentionDays)
+	}
+}
+
+func TestLoadFileEnvOverrides(t *testing.T) {
+	setEnv(t, map[string]string{
+		"STORAGE_FILE_PATH":     "/tmp/checks.jsonl",
+		"RETENTION_DAYS":        "7",
+		"PORT":                  "3000",
+		"SCHEDULER_CONCURRENCY": "2",
+		"LEADER_ELECTION":       "false",
+		"MONITORING_API_URL":    "https://legacy.example.com/health",
+		"MONITORING_API_SECRET": "secret",
+	})
+
+	cfg, err := Load(writeFile(t, "config.yaml", testYAML))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.StorageBackend != BackendFile || cfg.StorageFilePath != "/tmp/checks.jsonl" {
+		t.Errorf("storage = %q at %q", cfg.StorageBackend, cfg.StorageFilePath)
+	}
+	if cfg.LeaderElection {
+		t.Error("LEADER_ELECTION=false should override the file")
+	}
+	if cfg.RetentionDays != 7 || cfg.Port != "3000" || cfg.SchedulerConcurrency != 2 {
+		t.Errorf("RetentionDays = %d, Port = %q, SchedulerConcurrency = %d", cfg.RetentionDays, cfg.Port, cfg.SchedulerConcurrency)
+	}
+	if len(cfg.Targets) != 1 || cfg.Targets[0].URL != "https://legacy.example.com/health" {
+		t.Errorf("Targets = %+v, want the MONITORING_API_URL target", cfg.Targets)
+	}
+}
+
+func TestLoadFileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "unknown field",
+			content: "targets:\n  - url: http://a\nstorage:\n  backend: file\n  retention: 30\n",
+			wantErr: "field retention not found",
+		},
+		{
+			name:    "malformed yaml",
+			content: "targets: [\n",
+			wantErr: "invalid config file",
+		},
+		{
+			name:    "invalid target timeout",
+			content: "targets:\n  - url: http://a\n    timeout: soon\nstorage:\n  backend: file\n",
+			wantErr: "invalid config file",
+		},
+		{
+			name:    "invalid target",
+			content: "targets:\n  - name: a\n    url: http://a\n  - url: http://b\nstorage:\n  backend: file\n",
+			wantErr: "target 2: name is required",
+		},
+		{
+			name:    "negative retention",
+			content: "targets:\n  - url: http://a\nstorage:\n  backend: file\n  retentionDays: -1\n",
+			wantErr: "non-negative",
+		},
+		{
+			name:    "dynamodb without table",
+			content: "targets:\n  - url: http://a\nstorage:\n  awsRegion: ap-south-1\n",
+			wantErr: "storage.dynamodbTableName",
+		},
+		{
+			name:    "no targets",
+			content: "storage:\n  backend: file\n",
+			wantErr: "MONITORING_API_URL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setEnv(t, nil)
+
+			_, err := Load(writeFile(t, "config.yaml", tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Load() error = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	setEnv(t, nil)
+
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("Load() of a missing file succeeded")
+	}
+}
@@ -0,0 +1,121 @@
+package config
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/jsonpath"
+)
+
+// variablePattern matches ${name} references to variables extracted by
+// earlier steps
+var variablePattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// variableName matches the names variables can be extracted into
+var variableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Step is one HTTP request of a synthetic check. URL, Headers and Body may
+// reference variables extracted from earlier responses as ${name}.
+type Step struct {
+	Name         string            `json:"name" yaml:"name"`
+	Method       string            `json:"method" yaml:"method"`
+	URL          string            `json:"url" yaml:"url"`
+	Headers      map[string]string `json:"headers" yaml:"headers"`
+	Body         string            `json:"body" yaml:"body"`
+	ExpectStatus []int             `json:"expectStatus" yaml:"expectStatus"`
+	BodyContains string            `json:"bodyContains" yaml:"bodyContains"`
+	BodyMatches  string            `json:"bodyMatches" yaml:"bodyMatches"`
+	Assertions   []Assertion       `json:"assertions" yaml:"assertions"`
+	MaxLatency   Duration          `json:"maxLatency" yaml:"maxLatency"`
+	// Extract maps variable names to JSONPaths into the response body
+	Extract map[string]string `json:"extract" yaml:"extract"`
+}
+
+// Expand replaces ${name} references in text with their values
+func Expand(text string, variables map[string]string) string {
+	return variablePattern.ReplaceAllStringFunc(text, func(ref string) string {
+		return variables[variablePattern.FindStringSubmatch(ref)[1]]
+	})
+}
+
+// applyDefaults fills in the optional fields of the step at index i
+func (s *Step) applyDefaults(i int) {
+	if s.Name == "" {
+		s.Name = fmt.Sprintf("step %d", i+1)
+	}
+	if s.Method == "" {
+		s.Method = defaultMethod
+	}
+	s.Method = strings.ToUpper(s.Method)
+	defaultSeverities(s.Assertions)
+}
+
+// validateSteps checks a synthetic check's steps, including that every
+// variable is extracted by an earlier step than the one using it
+func (t *Target) validateSteps() error {
+	if len(t.Steps) == 0 {
+		return fmt.Errorf("steps are required for %q targets", KindSynthetic)
+	}
+
+	extracted := make(map[string]bool)
+	for i, step := range t.Steps {
+		if err := step.validate(extracted); err != nil {
+			return fmt.Errorf("step %d (%s): %w", i+1, step.Name, err)
+		}
+		for name := range step.Extract {
+			extracted[name] = true
+		}
+	}
+	return nil
+}
+
+// validate checks a step, given the variables extracted before it
+func (s *Step) validate(extracted map[string]bool) error {
+	if s.URL == "" {
+		return fmt.Errorf("url is required")
+	}
+	for _, code := range s.ExpectStatus {
+		if code < 100 || code > 599 {
+			return fmt.Errorf("expectStatus %d is not an HTTP status code", code)
+		}
+	}
+	if s.BodyMatches != "" {
+		if _, err := regexp.Compile(s.BodyMatches); err != nil {
+			return fmt.Errorf("bodyMatches is not a valid regular expression: %w", err)
+		}
+	}
+	if s.MaxLatency < 0 {
+		return fmt.Errorf("maxLatency must be positive")
+	}
+	if err := validateAssertions(s.Assertions, false); err != nil {
+		return err
+	}
+
+	for name, path := range s.Extract {
+		if !variableName.MatchString(name) {
+			return fmt.Errorf("extract: %q is not a valid variable name", name)
+		}
+		if _, err := jsonpath.ParsePath(path); err != nil {
+			return fmt.Errorf("extract %s: %w", name, err)
+		}
+	}
+
+	texts := []string{s.URL, s.Body}
+	for _, value := range s.Headers {
+		texts = append(texts, value)
+	}
+	for _, text := range texts {
+		for _, match := range variablePattern.FindAllStringSubmatch(text, -1) {
+			if !extracted[match[1]] {
+				return fmt.Errorf("${%s} is not extracted by an earlier step", match[1])
+			}
+		}
+	}
+
+	if _, err := http.NewRequest(s.Method, Expand(s.URL, nil), nil); err != nil {
+		return fmt.Errorf("invalid request: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,466 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/jsonpath"
+	"github.com/openlearnnitj/openlearn-monitoring/internal/models"
+)
+
+// Supported response formats of a health endpoint
+const (
+	// FormatComponents expects the HealthStatusResponse shape and records
+	// every component it lists
+	FormatComponents = "components"
+	// FormatOverall records only the response's overallStatus, as a single
+	// component named after the target
+	FormatOverall = "overall"
+)
+
+// Supported probe kinds
+const (
+	// KindHealth reads an OpenLearn health endpoint in one of the formats above
+	KindHealth = "health"
+	// KindHTTP requests an arbitrary URL and checks the response against the
+	// target's assertions
+	KindHTTP = "http"
+	// KindTCP dials a host:port, optionally exchanging a payload and banner
+	KindTCP = "tcp"
+	// KindTLS inspects the certificate chain served on a host:port
+	KindTLS = "tls"
+	// KindDNS resolves a hostname and checks the records returned
+	KindDNS = "dns"
+	// KindGRPC calls the grpc.health.v1.Health Check method of a server
+	KindGRPC = "grpc"
+	// KindSynthetic runs an ordered list of HTTP steps as one transaction
+	KindSynthetic = "synthetic"
+)
+
+// Redirect policies of HTTP probes
+const (
+	RedirectsFollow = "follow"
+	RedirectsNone   = "none"
+)
+
+// DNS record types supported by DNS probes
+var recordTypes = []string{"A", "AAAA", "CNAME", "TXT", "MX"}
+
+// Target defaults
+const (
+	defaultAuthHeader    = "X-API-Secret"
+	defaultTargetTimeout = 30 * time.Second
+	defaultMethod        = http.MethodGet
+	defaultRetryBackoff  = time.Second
+	defaultInterval      = time.Minute
+
+	// Certificates expiring within these many days are DEGRADED and DOWN
+	defaultCertWarningDays  = 14
+	defaultCertCriticalDays = 7
+
+	defaultRecordType = "A"
+	defaultDNSPort    = "53"
+)
+
+// Duration is a time.Duration that decodes from strings such as "10s"
+type Duration time.Duration
+
+// UnmarshalText parses a Go duration string
+func (d *Duration) UnmarshalText(text []byte) error {
+	parsed, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// Target is a single health endpoint checked by the monitoring service
+type Target struct {
+	// Name namespaces the target's component names ("api/Database"). The
+	// legacy single target configured by MONITORING_API_URL has no name, so
+	// its components keep their original names.
+	Name       string            `json:"name" yaml:"name"`
+	Kind       string            `json:"kind" yaml:"kind"`
+	URL        string            `json:"url" yaml:"url"`
+	AuthHeader string            `json:"authHeader" yaml:"authHeader"`
+	AuthValue  string            `json:"authValue" yaml:"authValue"`
+	Headers    map[string]string `json:"headers" yaml:"headers"`
+	Timeout    Duration          `json:"timeout" yaml:"timeout"`
+	Format     string            `json:"format" yaml:"format"`
+	Assertions []Assertion       `json:"assertions" yaml:"assertions"`
+	// Interval is how often the server's scheduler checks the target. The
+	// Lambda function checks every target on each scheduled invocation.
+	Interval Duration `json:"interval" yaml:"interval"`
+
+	// Retries re-runs a failed check up to this many times, waiting
+	// RetryBackoff before the first retry and doubling it after each one.
+	// ConfirmAfter is the number of consecutive failed checks before a
+	// component is recorded DOWN.
+	Retries      int      `json:"retries" yaml:"retries"`
+	RetryBackoff Duration `json:"retryBackoff" yaml:"retryBackoff"`
+	ConfirmAfter int      `json:"confirmAfter" yaml:"confirmAfter"`
+
+	// HTTP probe settings
+	Method       string   `json:"method" yaml:"method"`
+	Body         string   `json:"body" yaml:"body"`
+	ExpectStatus []int    `json:"expectStatus" yaml:"expectStatus"`
+	BodyContains string   `json:"bodyContains" yaml:"bodyContains"`
+	BodyMatches  string   `json:"bodyMatches" yaml:"bodyMatches"`
+	MaxLatency   Duration `json:"maxLatency" yaml:"maxLatency"`
+	Redirects    string   `json:"redirects" yaml:"redirects"`
+	// CheckCertificate also grades the expiry of an https URL's certificate
+	CheckCertificate bool `json:"checkCertificate" yaml:"checkCertificate"`
+
+	// TCP probe settings
+	Address      string `json:"address" yaml:"address"`
+	Send         string `json:"send" yaml:"send"`
+	ExpectBanner string `json:"expectBanner" yaml:"expectBanner"`
+
+	// gRPC probe settings. Service is the name passed to the health check;
+	// empty asks about the server as a whole.
+	Service       string `json:"service" yaml:"service"`
+	TLS           bool   `json:"tls" yaml:"tls"`
+	TLSSkipVerify bool   `json:"tlsSkipVerify" yaml:"tlsSkipVerify"`
+
+	// TLS settings: ServerName is also used by gRPC probes over TLS, the
+	// thresholds by HTTP probes checking certificates
+	ServerName       string `json:"serverName" yaml:"serverName"`
+	CertWarningDays  int    `json:"certWarningDays" yaml:"certWarningDays"`
+	CertCriticalDays int    `json:"certCriticalDays" yaml:"certCriticalDays"`
+
+	// DNS probe settings. Resolver is a host[:port]; the system resolver is
+	// used if it's empty.
+	Hostname   string   `json:"hostname" yaml:"hostname"`
+	RecordType string   `json:"recordType" yaml:"recordType"`
+	Resolver   string   `json:"resolver" yaml:"resolver"`
+	Expect     []string `json:"expect" yaml:"expect"`
+
+	// Synthetic check settings
+	Steps []Step `json:"steps" yaml:"steps"`
+}
+
+// Label names the target in logs and summaries: its name, or its URL if
+// it's unnamed
+func (t *Target) Label() string {
+	if t.Name == "" {
+		return t.URL
+	}
+	return t.Name
+}
+
+// ChecksCertificate reports whether the target grades certificate expiry
+func (t *Target) ChecksCertificate() bool {
+	return t.Kind == KindTLS || (t.Kind == KindHTTP && t.CheckCertificate)
+}
+
+// Assertion is a JSONPath expression, such as `$.db.replicationLag < 5`,
+// checked against a target's decoded response body
+type Assertion struct {
+	Expr string `json:"expr" yaml:"expr"`
+	// Severity is the status given to the covered components when the
+	// expression doesn't hold: DEGRADED or DOWN
+	Severity string `json:"severity" yaml:"severity"`
+	// Component limits the assertion to one component of a health endpoint
+	// using the components format, by its name in the response. By default
+	// it covers all of the target's components.
+	Component string `json:"component" yaml:"component"`
+}
+
+// loadTargets reads the targets from MONITORING_TARGETS (a JSON array) or
+// the single target described by MONITORING_API_URL and
+// MONITORING_API_SECRET. Either replaces the targets of the config file,
+// which are only used when neither is set.
+func loadTargets(cfg *Config) error {
+	if value := os.Getenv("MONITORING_TARGETS"); value != "" {
+		var targets []Target
+		if err := json.Unmarshal([]byte(value), &targets); err != nil {
+			return fmt.Errorf("MONITORING_TARGETS is not a valid JSON array of targets: %w", err)
+		}
+		if len(targets) == 0 {
+			return fmt.Errorf("MONITORING_TARGETS must list at least one target")
+		}
+		cfg.Targets = targets
+	} else if os.Getenv("MONITORING_API_URL") != "" || len(cfg.Targets) == 0 {
+		var err error
+		if cfg.MonitoringAPIURL, err = getEnvVar("MONITORING_API_URL"); err != nil {
+			return err
+		}
+
+		if cfg.MonitoringAPISecret, err = getEnvVar("MONITORING_API_SECRET"); err != nil {
+			return err
+		}
+
+		cfg.Targets = []Target{{
+			URL:       cfg.MonitoringAPIURL,
+			AuthValue: cfg.MonitoringAPISecret,
+		}}
+	}
+
+	seen := make(map[string]bool)
+	for i := range cfg.Targets {
+		target := &cfg.Targets[i]
+		target.applyDefaults()
+
+		if err := target.validate(len(cfg.Targets) > 1); err != nil {
+			return fmt.Errorf("target %d: %w", i+1, err)
+		}
+		if seen[target.Name] {
+			return fmt.Errorf("target %d: duplicate target name %q", i+1, target.Name)
+		}
+		seen[target.Name] = true
+	}
+
+	return nil
+}
+
+// applyDefaults fills in optional fields
+func (t *Target) applyDefaults() {
+	if t.Kind == "" {
+		t.Kind = KindHealth
+	}
+	if t.AuthHeader == "" {
+		t.AuthHeader = defaultAuthHeader
+	}
+	if t.Timeout == 0 {
+		t.Timeout = Duration(defaultTargetTimeout)
+	}
+	if t.Format == "" {
+		t.Format = FormatComponents
+	}
+	if t.Interval == 0 {
+		t.Interval = Duration(defaultInterval)
+	}
+	if t.Retries > 0 && t.RetryBackoff == 0 {
+		t.RetryBackoff = Duration(defaultRetryBackoff)
+	}
+	if t.ConfirmAfter == 0 {
+		t.ConfirmAfter = 1
+	}
+	defaultSeverities(t.Assertions)
+	if t.Kind == KindHTTP {
+		if t.Method == "" {
+			t.Method = defaultMethod
+		}
+		t.Method = strings.ToUpper(t.Method)
+		if t.Redirects == "" {
+			t.Redirects = RedirectsFollow
+		}
+	}
+	if t.Kind == KindDNS {
+		if t.RecordType == "" {
+			t.RecordType = defaultRecordType
+		}
+		t.RecordType = strings.ToUpper(t.RecordType)
+		if t.Resolver != "" {
+			if _, _, err := net.SplitHostPort(t.Resolver); err != nil {
+				t.Resolver = net.JoinHostPort(t.Resolver, defaultDNSPort)
+			}
+		}
+	}
+	for i := range t.Steps {
+		t.Steps[i].applyDefaults(i)
+	}
+	if t.ChecksCertificate() {
+		if t.CertWarningDays == 0 {
+			t.CertWarningDays = defaultCertWarningDays
+		}
+		if t.CertCriticalDays == 0 {
+			t.CertCriticalDays = defaultCertCriticalDays
+		}
+	}
+}
+
+// defaultSeverities makes failing assertions mark components DOWN unless
+// configured otherwise
+func defaultSeverities(assertions []Assertion) {
+	for i := range assertions {
+		if assertions[i].Severity == "" {
+			assertions[i].Severity = models.StatusDown
+		}
+	}
+}
+
+// validate checks a target's fields. Names are required once several
+// targets are configured so their components can't collide.
+func (t *Target) validate(named bool) error {
+	if named && t.Name == "" {
+		return fmt.Errorf("name is required when more than one target is configured")
+	}
+	if t.Timeout < 0 {
+		return fmt.Errorf("timeout must be positive")
+	}
+	if t.MaxLatency < 0 {
+		return fmt.Errorf("maxLatency must be positive")
+	}
+	if t.Interval < 0 {
+		return fmt.Errorf("interval must be positive")
+	}
+	if t.Retries < 0 {
+		return fmt.Errorf("retries can't be negative")
+	}
+	if t.RetryBackoff < 0 {
+		return fmt.Errorf("retryBackoff must be positive")
+	}
+	if t.ConfirmAfter < 0 {
+		return fmt.Errorf("confirmAfter can't be negative")
+	}
+	// Probes other than health endpoints record a single component named
+	// after the target
+	if t.Kind != KindHealth && t.Name == "" {
+		return fmt.Errorf("name is required for %q targets", t.Kind)
+	}
+
+	// Only probes reading a response body can evaluate assertions
+	if len(t.Assertions) > 0 && t.Kind != KindHealth && t.Kind != KindHTTP {
+		return fmt.Errorf("assertions are not supported for %q targets", t.Kind)
+	}
+
+	switch t.Kind {
+	case KindHealth:
+		return t.validateHealth()
+	case KindHTTP:
+		return t.validateHTTP()
+	case KindTCP:
+		return t.validateTCP()
+	case KindTLS:
+		return t.validateTLS()
+	case KindDNS:
+		return t.validateDNS()
+	case KindGRPC:
+		return t.validateGRPC()
+	case KindSynthetic:
+		return t.validateSteps()
+	default:
+		return fmt.Errorf("unsupported kind %q", t.Kind)
+	}
+}
+
+// validateHealth checks the settings of a health endpoint target
+func (t *Target) validateHealth() error {
+	if t.URL == "" {
+		return fmt.Errorf("url is required")
+	}
+	switch t.Format {
+	case FormatComponents:
+	case FormatOverall:
+		if t.Name == "" {
+			return fmt.Errorf("name is required for the %q format", FormatOverall)
+		}
+	default:
+		return fmt.Errorf("unsupported format %q", t.Format)
+	}
+	return validateAssertions(t.Assertions, t.Format == FormatComponents)
+}
+
+// validateHTTP checks the settings of a generic HTTP probe
+func (t *Target) validateHTTP() error {
+	if t.URL == "" {
+		return fmt.Errorf("url is required")
+	}
+	for _, code := range t.ExpectStatus {
+		if code < 100 || code > 599 {
+			return fmt.Errorf("expectStatus %d is not an HTTP status code", code)
+		}
+	}
+	if t.BodyMatches != "" {
+		if _, err := regexp.Compile(t.BodyMatches); err != nil {
+			return fmt.Errorf("bodyMatches is not a valid regular expression: %w", err)
+		}
+	}
+	if t.Redirects != RedirectsFollow && t.Redirects != RedirectsNone {
+		return fmt.Errorf("unsupported redirects policy %q", t.Redirects)
+	}
+	if t.CheckCertificate {
+		if !strings.HasPrefix(strings.ToLower(t.URL), "https://") {
+			return fmt.Errorf("checkCertificate requires an https url")
+		}
+		if err := t.validateCertThresholds(); err != nil {
+			return err
+		}
+	}
+	return validateAssertions(t.Assertions, false)
+}
+
+// validateTCP checks the settings of a TCP probe
+func (t *Target) validateTCP() error {
+	if _, _, err := net.SplitHostPort(t.Address); err != nil {
+		return fmt.Errorf("address must be host:port: %w", err)
+	}
+	return nil
+}
+
+// validateTLS checks the settings of a TLS certificate probe
+func (t *Target) validateTLS() error {
+	if _, _, err := net.SplitHostPort(t.Address); err != nil {
+		return fmt.Errorf("address must be host:port: %w", err)
+	}
+	return t.validateCertThresholds()
+}
+
+// validateDNS checks the settings of a DNS probe
+func (t *Target) validateDNS() error {
+	if t.Hostname == "" {
+		return fmt.Errorf("hostname is required")
+	}
+	if !slices.Contains(recordTypes, t.RecordType) {
+		return fmt.Errorf("unsupported recordType %q, want one of %s", t.RecordType, strings.Join(recordTypes, ", "))
+	}
+	if t.Resolver != "" {
+		if _, _, err := net.SplitHostPort(t.Resolver); err != nil {
+			return fmt.Errorf("resolver must be host or host:port: %w", err)
+		}
+	}
+	if t.RecordType == "A" || t.RecordType == "AAAA" {
+		for _, value := range t.Expect {
+			if net.ParseIP(value) == nil {
+				return fmt.Errorf("expect value %q is not an IP address", value)
+			}
+		}
+	}
+	return nil
+}
+
+// validateGRPC checks the settings of a gRPC health probe
+func (t *Target) validateGRPC() error {
+	if _, _, err := net.SplitHostPort(t.Address); err != nil {
+		return fmt.Errorf("address must be host:port: %w", err)
+	}
+	if t.TLSSkipVerify && !t.TLS {
+		return fmt.Errorf("tlsSkipVerify requires tls")
+	}
+	return nil
+}
+
+// validateCertThresholds checks that the critical threshold is the tighter one
+func (t *Target) validateCertThresholds() error {
+	if t.CertCriticalDays < 0 || t.CertWarningDays < t.CertCriticalDays {
+		return fmt.Errorf("certWarningDays (%d) must be at least certCriticalDays (%d), and both positive", t.CertWarningDays, t.CertCriticalDays)
+	}
+	return nil
+}
+
+// validateAssertions parses every assertion so bad expressions fail at
+// startup. Only targets reporting several components can scope an
+// assertion to one of them.
+func validateAssertions(assertions []Assertion, components bool) error {
+	for i, assertion := range assertions {
+		if _, err := jsonpath.ParseAssertion(assertion.Expr); err != nil {
+			return fmt.Errorf("assertion %d: %w", i+1, err)
+		}
+		if assertion.Severity != models.StatusDegraded && assertion.Severity != models.StatusDown {
+			return fmt.Errorf("assertion %d: severity must be %s or %s, got %q", i+1, models.StatusDegraded, models.StatusDown, assertion.Severity)
+		}
+		if assertion.Component != "" && !components {
+			return fmt.Errorf("assertion %d: component only applies to %q targets with the %q format", i+1, KindHealth, FormatComponents)
+		}
+	}
+	return nil
+}
@@ -4,44 +4,235 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sync"
+	"time"
 
+	"github.com/openlearnnitj/openlearn-monitoring/internal/alerting"
+	"github.com/openlearnnitj/openlearn-monitoring/internal/config"
+	"github.com/openlearnnitj/openlearn-monitoring/internal/models"
 	"github.com/openlearnnitj/openlearn-monitoring/internal/monitoring"
 	"github.com/openlearnnitj/openlearn-monitoring/internal/storage"
 )
 
+// storeReserve is kept back from the run's deadline for storing results, so
+// a probe that runs out the clock is still recorded as a timeout rather than
+// lost when the invocation is killed
+const storeReserve = 5 * time.Second
+
 // Handler manages the Lambda function execution
 type Handler struct {
 	monitoringService *monitoring.Service
 	storageService    *storage.Service
+	alertingService   *alerting.Service // nil disables alerts
+
+	// flights holds the checks in progress by target label
+	mu      sync.Mutex
+	flights map[string]*flight
 }
 
-// NewHandler creates a new handler instance
-func NewHandler(monitoringService *monitoring.Service, storageService *storage.Service) *Handler {
+// flight is a check of one target in progress, shared by every caller that
+// asks for the same target before it completes
+type flight struct {
+	done   chan struct{}
+	result *models.MonitoringResult
+	err    error
+}
+
+// NewHandler creates a new handler instance. Alerts about components
+// changing status are sent through alertingService unless it's nil.
+func NewHandler(monitoringService *monitoring.Service, storageService *storage.Service, alertingService *alerting.Service) *Handler {
 	return &Handler{
 		monitoringService: monitoringService,
 		storageService:    storageService,
+		alertingService:   alertingService,
+		flights:           make(map[string]*flight),
 	}
 }
 
-// Handle processes the Lambda function request
-func (h *Handler) Handle(ctx context.Context) error {
+// Summary describes the outcome of a monitoring run
+type Summary struct {
+	Timestamp           time.Time         `json:"timestamp"`
+	Targets             int               `json:"targets"`
+	Components          int               `json:"components"`
+	TotalResponseTimeMs int64             `json:"totalResponseTimeMs"` // slowest target
+	Statuses            map[string]string `json:"statuses"`
+	Errors              map[string]string `json:"errors,omitempty"` // by target name
+}
+
+// Handle checks every configured target concurrently and stores the results.
+// Unreachable targets are stored as DOWN. A target whose results can't be
+// stored doesn't prevent the others from being stored; its error is reported
+// in the summary and the returned error.
+func (h *Handler) Handle(ctx context.Context) (*Summary, error) {
 	log.Println("Starting monitoring service execution")
 
-	// Perform health check
-	result, err := h.monitoringService.CheckHealth()
+	targets := h.monitoringService.Targets()
+	summary := &Summary{
+		Timestamp: time.Now().UTC(),
+		Targets:   len(targets),
+		Statuses:  make(map[string]string),
+	}
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+
+	for _, target := range targets {
+		wg.Add(1)
+		go func(target config.Target) {
+			defer wg.Done()
+
+			result, err := h.CheckTarget(ctx, target)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if summary.Errors == nil {
+					summary.Errors = make(map[string]string)
+				}
+				summary.Errors[target.Label()] = err.Error()
+				return
+			}
+
+			summary.Components += len(result.Components)
+			if result.TotalResponseTimeMs > summary.TotalResponseTimeMs {
+				summary.TotalResponseTimeMs = result.TotalResponseTimeMs
+			}
+			for _, component := range result.Components {
+				summary.Statuses[component.Name] = component.Status
+			}
+		}(target)
+	}
+
+	wg.Wait()
+
+	if len(summary.Errors) > 0 {
+		return summary, fmt.Errorf("%d of %d targets failed: %v", len(summary.Errors), len(targets), summary.Errors)
+	}
+
+	return summary, nil
+}
+
+// CheckTarget performs a health check against one target and stores the
+// result. Callers asking for a target that is already being checked, such
+// as overlapping /monitor triggers and the scheduler, wait for that check
+// and share its result instead of probing and storing it again.
+func (h *Handler) CheckTarget(ctx context.Context, target config.Target) (*models.MonitoringResult, error) {
+	label := target.Label()
+
+	h.mu.Lock()
+	if f, ok := h.flights[label]; ok {
+		h.mu.Unlock()
+		log.Printf("Check of %s already in progress, waiting for its result", label)
+		select {
+		case <-f.done:
+			return f.result, f.err
+		case <-ctx.Done():
+			return nil, fmt.Errorf("gave up waiting for the check in progress: %w", ctx.Err())
+		}
+	}
+	f := &flight{done: make(chan struct{})}
+	h.flights[label] = f
+	h.mu.Unlock()
+
+	f.result, f.err = h.checkTarget(ctx, target)
+
+	h.mu.Lock()
+	delete(h.flights, label)
+	h.mu.Unlock()
+	close(f.done)
+
+	return f.result, f.err
+}
+
+// checkTarget performs a health check against one target and stores the result
+func (h *Handler) checkTarget(ctx context.Context, target config.Target) (*models.MonitoringResult, error) {
+	label := target.Label()
+
+	// Perform health check. A target that can't be checked is an outage
+	// like any other, so it's stored as DOWN rather than skipped.
+	probeCtx, cancel := probeContext(ctx)
+	defer cancel()
+	result, err := h.monitoringService.CheckHealth(probeCtx, target)
+	if err != nil && ctx.Err() == context.Canceled {
+		// The check was called off, e.g. by its scheduler losing the lease;
+		// the target didn't fail
+		return nil, fmt.Errorf("check of %s was cancelled: %w", label, err)
+	}
+	if err != nil {
+		log.Printf("Health check of %s failed: %v", label, err)
+		result = monitoring.FailedResult(target, err)
+		result.Attempts = monitoring.Attempts(err)
+	} else {
+		log.Printf("Health check of %s completed successfully. Found %d components. Total response time: %dms",
+			label, len(result.Components), result.TotalResponseTimeMs)
+	}
+
+	// What was found is stored even if the check is called off meanwhile
+	ctx, cancelStore := storeContext(ctx)
+	defer cancelStore()
+
+	// Read what's stored before it's overwritten, once for confirming
+	// failures, storing and alerting on the changes
+	previous, err := h.storageService.LatestStored(ctx, result)
 	if err != nil {
-		return fmt.Errorf("health check failed: %w", err)
+		// The raw status is still worth storing. Without the history every
+		// failing component would look new, so no alerts are sent.
+		log.Printf("Failed to read the previous statuses of %s, storing them unconfirmed and not alerting: %v", label, err)
+		previous = nil
+	} else {
+		// Hold back DOWN until it's been seen on enough consecutive checks
+		storage.ConfirmFailures(result, previous, target.ConfirmAfter)
 	}
 
-	log.Printf("Health check completed successfully. Found %d components. Total response time: %dms",
-		len(result.Components), result.TotalResponseTimeMs)
+	// Store results in the configured backend
+	if err := h.storageService.StoreResultsWithPrevious(ctx, result, previous); err != nil {
+		log.Printf("Failed to store results of %s: %v", label, err)
+		return nil, fmt.Errorf("failed to store results: %w", err)
+	}
 
-	// Store results in DynamoDB
-	if err := h.storageService.StoreResults(ctx, result); err != nil {
-		return fmt.Errorf("failed to store results: %w", err)
+	log.Printf("Successfully stored %d component statuses of %s", len(result.Components), label)
+
+	// Alerts are sent once the statuses they report are stored. A channel
+	// that can't be reached doesn't fail the check.
+	if h.alertingService != nil && previous != nil {
+		if err := h.alertingService.Notify(ctx, previous, result); err != nil {
+			log.Printf("Failed to send alerts for %s: %v", label, err)
+		}
 	}
 
-	log.Printf("Successfully stored %d component statuses to DynamoDB", len(result.Components))
+	return result, nil
+}
+
+// probeContext derives the context probes run under, ending storeReserve
+// before ctx's deadline, or halfway to it if that's sooner
+func probeContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return context.WithCancel(ctx)
+	}
+	reserve := min(storeReserve, time.Until(deadline)/2)
+	return context.WithDeadline(ctx, deadline.Add(-reserve))
+}
 
-	return nil
+// storeContext derives the context results are stored under. It ends with
+// ctx's deadline, but outlives a cancellation of ctx by up to storeReserve.
+func storeContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	var (
+		storeCtx context.Context
+		cancel   context.CancelFunc
+	)
+	if deadline, ok := ctx.Deadline(); ok {
+		storeCtx, cancel = context.WithDeadline(context.WithoutCancel(ctx), deadline)
+	} else {
+		storeCtx, cancel = context.WithCancel(context.WithoutCancel(ctx))
+	}
+	stop := context.AfterFunc(ctx, func() {
+		time.AfterFunc(storeReserve, cancel)
+	})
+	return storeCtx, func() {
+		stop()
+		cancel()
+	}
 }
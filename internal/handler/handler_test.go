@@ -0,0 +1,404 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/alerting"
+	"github.com/openlearnnitj/openlearn-monitoring/internal/config"
+	"github.com/openlearnnitj/openlearn-monitoring/internal/models"
+	"github.com/openlearnnitj/openlearn-monitoring/internal/monitoring"
+	"github.com/openlearnnitj/openlearn-monitoring/internal/status"
+	"github.com/openlearnnitj/openlearn-monitoring/internal/storage"
+)
+
+func healthServer(t *testing.T, status int, body string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestHandleMultipleTargets(t *testing.T) {
+	api := healthServer(t, http.StatusOK, `{"components": [{"name": "Database", "status": "OPERATIONAL"}]}`)
+	auth := healthServer(t, http.StatusOK, `{"components": [{"name": "Database", "status": "DOWN"}]}`)
+	broken := healthServer(t, http.StatusBadGateway, "")
+
+	targets := []config.Target{
+		{Name: "api", URL: api.URL, Timeout: config.Duration(time.Second), Format: config.FormatComponents},
+		{Name: "auth", URL: auth.URL, Timeout: config.Duration(time.Second), Format: config.FormatComponents},
+		{Name: "workers", URL: broken.URL, Timeout: config.Duration(time.Second), Format: config.FormatComponents},
+	}
+
+	backend := storage.NewMemoryBackend()
+	h := NewHandler(monitoring.NewService(targets), storage.NewService(backend, 0), nil)
+
+	summary, err := h.Handle(context.Background())
+	if err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+
+	if summary.Targets != 3 || summary.Components != 5 {
+		t.Errorf("summary = %+v, want 3 targets and 5 components", summary)
+	}
+	if len(summary.Errors) != 0 {
+		t.Errorf("Errors = %v, want none", summary.Errors)
+	}
+
+	// Components with the same name on different targets are stored separately
+	latest, err := backend.Latest(context.Background())
+	if err != nil {
+		t.Fatalf("Latest() error = %v", err)
+	}
+	got := make(map[string]string)
+	for _, record := range latest {
+		got[record.ServiceName] = record.Status
+	}
+	if got["api/Database"] != "OPERATIONAL" || got["auth/Database"] != "DOWN" || len(got) != 5 {
+		t.Errorf("stored statuses = %v", got)
+	}
+
+	// The unreachable target is recorded as an outage, the reachable ones
+	// as operational
+	if got["workers/target"] != "DOWN" || got["api/target"] != "OPERATIONAL" {
+		t.Errorf("stored statuses = %v, want workers/target DOWN and api/target OPERATIONAL", got)
+	}
+}
+
+func TestHandleUnreachableTarget(t *testing.T) {
+	down := healthServer(t, http.StatusOK, "")
+	down.Close()
+
+	tests := []struct {
+		name      string
+		target    config.Target
+		wantName  string
+		wantClass string
+	}{
+		{
+			name:      "connection refused",
+			target:    config.Target{Name: "api", URL: down.URL, Timeout: config.Duration(time.Second), Format: config.FormatComponents},
+			wantName:  "api/target",
+			wantClass: "connection",
+		},
+		{
+			name:      "invalid response",
+			target:    config.Target{Name: "api", URL: healthServer(t, http.StatusOK, "<html>").URL, Timeout: config.Duration(time.Second), Format: config.FormatComponents},
+			wantName:  "api/target",
+			wantClass: "response",
+		},
+		{
+			name:      "overall format keeps the target's component",
+			target:    config.Target{Name: "cdn", URL: healthServer(t, http.StatusServiceUnavailable, "").URL, Timeout: config.Duration(time.Second), Format: config.FormatOverall},
+			wantName:  "cdn",
+			wantClass: "status",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := storage.NewMemoryBackend()
+			h := NewHandler(monitoring.NewService([]config.Target{tt.target}), storage.NewService(backend, 0), nil)
+
+			if _, err := h.Handle(context.Background()); err != nil {
+				t.Fatalf("Handle() error = %v", err)
+			}
+
+			latest, _ := backend.Latest(context.Background())
+			if len(latest) != 1 {
+				t.Fatalf("got %d records, want 1", len(latest))
+			}
+			record := latest[0]
+			if record.ServiceName != tt.wantName || record.Status != "DOWN" || record.ErrorClass != tt.wantClass || record.Message == "" {
+				t.Errorf("record = %+v, want %s DOWN with a %s error", record, tt.wantName, tt.wantClass)
+			}
+		})
+	}
+}
+
+func TestHandleStoresAttemptsOfFailedRetries(t *testing.T) {
+	target := config.Target{
+		Name: "cdn", URL: healthServer(t, http.StatusServiceUnavailable, "").URL, Timeout: config.Duration(time.Second),
+		Format: config.FormatOverall, Retries: 2, RetryBackoff: config.Duration(time.Millisecond),
+	}
+	backend := storage.NewMemoryBackend()
+	h := NewHandler(monitoring.NewService([]config.Target{target}), storage.NewService(backend, 0), nil)
+
+	if _, err := h.Handle(context.Background()); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+
+	record, ok, _ := backend.Last(context.Background(), "cdn")
+	if !ok || record.Status != "DOWN" || record.ErrorClass != "status" {
+		t.Fatalf("record = %+v, want cdn DOWN with a status error", record)
+	}
+	if len(record.Attempts) != 3 {
+		t.Fatalf("stored %d attempts, want 3", len(record.Attempts))
+	}
+	for i, attempt := range record.Attempts {
+		if attempt.Status != "DOWN" {
+			t.Errorf("attempt %d is %s, want DOWN", i+1, attempt.Status)
+		}
+	}
+}
+
+// countingBackend counts the reads of each component's latest check
+type countingBackend struct {
+	storage.Backend
+	lasts atomic.Int32
+}
+
+func (b *countingBackend) Last(ctx context.Context, serviceName string) (models.CheckRecord, bool, error) {
+	b.lasts.Add(1)
+	return b.Backend.Last(ctx, serviceName)
+}
+
+func TestHandleReadsPreviousChecksOnce(t *testing.T) {
+	api := healthServer(t, http.StatusOK, `{"components": [{"name": "Database", "status": "DOWN"}, {"name": "Cache", "status": "OPERATIONAL"}]}`)
+	targets := []config.Target{
+		{Name: "api", URL: api.URL, Timeout: config.Duration(time.Second), Format: config.FormatComponents, ConfirmAfter: 2},
+	}
+	backend := &countingBackend{Backend: storage.NewMemoryBackend()}
+	h := NewHandler(monitoring.NewService(targets), storage.NewService(backend, 0),
+		alerting.NewService([]alerting.Notifier{&recordingNotifier{}}))
+
+	if _, err := h.Handle(context.Background()); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	latest, _ := backend.Latest(context.Background())
+	if got := backend.lasts.Load(); int(got) != len(latest) {
+		t.Errorf("read the latest check %d times, want once for each of %d components", got, len(latest))
+	}
+}
+
+func TestHandleStoresTimeoutBeforeDeadline(t *testing.T) {
+	hanging := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	t.Cleanup(hanging.Close)
+
+	targets := []config.Target{
+		{Name: "api", URL: hanging.URL, Timeout: config.Duration(30 * time.Second), Format: config.FormatComponents},
+	}
+	backend := storage.NewMemoryBackend()
+	h := NewHandler(monitoring.NewService(targets), storage.NewService(backend, 0), nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 400*time.Millisecond)
+	defer cancel()
+	if _, err := h.Handle(ctx); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+
+	latest, _ := backend.Latest(context.Background())
+	if len(latest) != 1 || latest[0].Status != "DOWN" || latest[0].ErrorClass != "timeout" {
+		t.Errorf("Latest() = %+v, want a stored timeout", latest)
+	}
+}
+
+func TestHandleCoalescesConcurrentRuns(t *testing.T) {
+	var requests atomic.Int32
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		time.Sleep(100 * time.Millisecond)
+		w.Write([]byte(`{"components": [{"name": "Database", "status": "OPERATIONAL"}]}`))
+	}))
+	t.Cleanup(api.Close)
+
+	targets := []config.Target{
+		{Name: "api", URL: api.URL, Timeout: config.Duration(time.Second), Format: config.FormatComponents},
+	}
+	backend := storage.NewMemoryBackend()
+	h := NewHandler(monitoring.NewService(targets), storage.NewService(backend, 0), nil)
+
+	// Overlapping triggers share the check in progress
+	var wg sync.WaitGroup
+	summaries := make([]*Summary, 3)
+	for i := range summaries {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			summary, err := h.Handle(context.Background())
+			if err != nil {
+				t.Errorf("Handle() error = %v", err)
+			}
+			summaries[i] = summary
+		}(i)
+	}
+	wg.Wait()
+
+	if requests.Load() != 1 {
+		t.Errorf("target was probed %d times, want 1", requests.Load())
+	}
+	for i, summary := range summaries {
+		if summary == nil || summary.Statuses["api/Database"] != "OPERATIONAL" {
+			t.Errorf("summary %d = %+v, want the shared result", i, summary)
+		}
+	}
+	records, _ := backend.Range(context.Background(), "api/Database", time.Now().Add(-time.Minute), time.Now())
+	if len(records) != 1 {
+		t.Errorf("stored %d checks, want 1", len(records))
+	}
+
+	// A later trigger runs a new check
+	if _, err := h.Handle(context.Background()); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if requests.Load() != 2 {
+		t.Errorf("target was probed %d times, want 2", requests.Load())
+	}
+}
+
+// recordingNotifier keeps the alerts it's sent
+type recordingNotifier struct {
+	mu     sync.Mutex
+	alerts []alerting.Alert
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, alert alerting.Alert) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.alerts = append(n.alerts, alert)
+	return nil
+}
+
+func TestHandleAlertsOnStatusChanges(t *testing.T) {
+	var status atomic.Value
+	status.Store("OPERATIONAL")
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"components": [{"name": "Database", "status": "` + status.Load().(string) + `"}]}`))
+	}))
+	t.Cleanup(api.Close)
+
+	targets := []config.Target{
+		{Name: "api", URL: api.URL, Timeout: config.Duration(time.Second), Format: config.FormatComponents},
+	}
+	notifier := &recordingNotifier{}
+	h := NewHandler(monitoring.NewService(targets), storage.NewService(storage.NewMemoryBackend(), 0),
+		alerting.NewService([]alerting.Notifier{notifier}))
+
+	for _, next := range []string{"OPERATIONAL", "OPERATIONAL", "DOWN", "DOWN", "DEGRADED", "OPERATIONAL"} {
+		status.Store(next)
+		if _, err := h.Handle(context.Background()); err != nil {
+			t.Fatalf("Handle() error = %v", err)
+		}
+	}
+
+	var got []string
+	for _, alert := range notifier.alerts {
+		got = append(got, alert.PreviousStatus+" -> "+alert.Status)
+	}
+	want := []string{"OPERATIONAL -> DOWN", "DOWN -> DEGRADED", "DEGRADED -> OPERATIONAL"}
+	if len(got) != len(want) {
+		t.Fatalf("alerts = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("alert %d = %s, want %s", i+1, got[i], want[i])
+		}
+	}
+
+	// The recovery covers the whole outage, not just the last status
+	down, recovered := notifier.alerts[0], notifier.alerts[2]
+	if !recovered.Recovered() || !recovered.OutageStart.Equal(down.Timestamp) ||
+		recovered.OutageDuration != recovered.Timestamp.Sub(down.Timestamp) {
+		t.Errorf("recovery = %+v, want the outage since %s", recovered, down.Timestamp)
+	}
+}
+
+func TestHandleRecoversUnreachableTarget(t *testing.T) {
+	var failing atomic.Bool
+	failing.Store(true)
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"components": [{"name": "Database", "status": "OPERATIONAL"}]}`))
+	}))
+	t.Cleanup(api.Close)
+
+	targets := []config.Target{
+		{Name: "api", URL: api.URL, Timeout: config.Duration(time.Second), Format: config.FormatComponents},
+	}
+	backend := storage.NewMemoryBackend()
+	notifier := &recordingNotifier{}
+	h := NewHandler(monitoring.NewService(targets), storage.NewService(backend, 0),
+		alerting.NewService([]alerting.Notifier{notifier}))
+
+	// Fails once, then answers
+	for _, fail := range []bool{true, false} {
+		failing.Store(fail)
+		if _, err := h.Handle(context.Background()); err != nil {
+			t.Fatalf("Handle() error = %v", err)
+		}
+	}
+
+	page, err := status.NewStatusService(backend).GetCurrentStatus(context.Background())
+	if err != nil {
+		t.Fatalf("GetCurrentStatus() error = %v", err)
+	}
+	if page.OverallStatus != "OPERATIONAL" {
+		t.Errorf("OverallStatus = %s, want OPERATIONAL", page.OverallStatus)
+	}
+	for _, component := range page.Components {
+		if component.Status != "OPERATIONAL" {
+			t.Errorf("%s is %s, want OPERATIONAL", component.Name, component.Status)
+		}
+	}
+
+	if len(notifier.alerts) != 2 {
+		t.Fatalf("alerts = %+v, want the outage and the recovery", notifier.alerts)
+	}
+	if down, recovered := notifier.alerts[0], notifier.alerts[1]; down.Component != "api/target" || down.Status != "DOWN" ||
+		recovered.Component != "api/target" || !recovered.Recovered() {
+		t.Errorf("alerts = %+v, want api/target DOWN then recovered", notifier.alerts)
+	}
+}
+
+func TestCheckTargetCancelled(t *testing.T) {
+	started := make(chan struct{})
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-r.Context().Done()
+	}))
+	t.Cleanup(api.Close)
+
+	target := config.Target{Name: "api", URL: api.URL, Timeout: config.Duration(time.Second), Format: config.FormatComponents}
+	backend := storage.NewMemoryBackend()
+	h := NewHandler(monitoring.NewService([]config.Target{target}), storage.NewService(backend, 0), nil)
+
+	// A check called off mid-probe isn't an outage, so nothing is stored
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+	if _, err := h.CheckTarget(ctx, target); err == nil {
+		t.Fatal("CheckTarget() succeeded after being cancelled")
+	}
+	if latest, _ := backend.Latest(context.Background()); len(latest) != 0 {
+		t.Errorf("stored %+v for a cancelled check", latest)
+	}
+}
+
+func TestStoreContextOutlivesCancellation(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	storeCtx, cancelStore := storeContext(ctx)
+	defer cancelStore()
+
+	cancel()
+	if err := storeCtx.Err(); err != nil {
+		t.Errorf("store context error = %v right after cancelling its parent, want the store reserve", err)
+	}
+	if deadline, _ := storeCtx.Deadline(); deadline.After(time.Now().Add(time.Minute)) {
+		t.Errorf("store context deadline = %s, want its parent's", deadline)
+	}
+}
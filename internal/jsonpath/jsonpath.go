@@ -0,0 +1,195 @@
+// Package jsonpath evaluates simple JSONPath assertions such as
+// `$.db.replicationLag < 5` against decoded JSON documents.
+package jsonpath
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Supported comparison operators, longest first so ">=" isn't read as ">"
+var operators = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+// Path is a parsed JSONPath made of object keys and array indexes
+type Path struct {
+	raw      string
+	segments []any // string keys and int indexes
+}
+
+// ParsePath parses a path such as `$.components[0]["response time"]`
+func ParsePath(raw string) (Path, error) {
+	path, rest, err := parsePath(raw)
+	if err != nil {
+		return Path{}, err
+	}
+	if rest != "" {
+		return Path{}, fmt.Errorf("path %q has unexpected %q", raw, rest)
+	}
+	return path, nil
+}
+
+// parsePath parses the path at the start of expr and returns the rest
+func parsePath(expr string) (Path, string, error) {
+	if !strings.HasPrefix(expr, "$") {
+		return Path{}, "", fmt.Errorf("path %q must start with $", expr)
+	}
+
+	var path Path
+	rest := expr[1:]
+	for rest != "" && (rest[0] == '.' || rest[0] == '[') {
+		if rest[0] == '.' {
+			end := 1 + strings.IndexFunc(rest[1:], func(r rune) bool {
+				return strings.ContainsRune(".[ =!<>", r)
+			})
+			if end == 0 {
+				end = len(rest)
+			}
+			if end == 1 {
+				return Path{}, "", fmt.Errorf("path %q has an empty key", expr)
+			}
+			path.segments = append(path.segments, rest[1:end])
+			rest = rest[end:]
+			continue
+		}
+
+		end := strings.IndexByte(rest, ']')
+		if end < 0 {
+			return Path{}, "", fmt.Errorf("path %q has an unclosed [", expr)
+		}
+		inner := rest[1:end]
+		if key, err := strconv.Unquote(inner); err == nil {
+			path.segments = append(path.segments, key)
+		} else if index, err := strconv.Atoi(inner); err == nil && index >= 0 {
+			path.segments = append(path.segments, index)
+		} else {
+			return Path{}, "", fmt.Errorf("path %q has an invalid index [%s]", expr, inner)
+		}
+		rest = rest[end+1:]
+	}
+
+	path.raw = expr[:len(expr)-len(rest)]
+	return path, rest, nil
+}
+
+// String returns the path as written
+func (p Path) String() string {
+	return p.raw
+}
+
+// Lookup returns the value at the path, and whether it exists
+func (p Path) Lookup(doc any) (any, bool) {
+	value := doc
+	for _, segment := range p.segments {
+		switch segment := segment.(type) {
+		case string:
+			object, ok := value.(map[string]any)
+			if !ok {
+				return nil, false
+			}
+			if value, ok = object[segment]; !ok {
+				return nil, false
+			}
+		case int:
+			array, ok := value.([]any)
+			if !ok || segment >= len(array) {
+				return nil, false
+			}
+			value = array[segment]
+		}
+	}
+	return value, true
+}
+
+// Assertion checks the value at a path. Without an operator it only
+// requires the value to exist.
+type Assertion struct {
+	Path     Path
+	Operator string
+	Value    any
+}
+
+// ParseAssertion parses an expression of the form `<path> [<op> <value>]`,
+// where the value is a JSON literal such as 5, "UP" or true
+func ParseAssertion(expr string) (*Assertion, error) {
+	path, rest, err := parsePath(strings.TrimSpace(expr))
+	if err != nil {
+		return nil, err
+	}
+	assertion := &Assertion{Path: path}
+
+	rest = strings.TrimSpace(rest)
+	if rest == "" {
+		return assertion, nil
+	}
+	for _, op := range operators {
+		if strings.HasPrefix(rest, op) {
+			assertion.Operator = op
+			break
+		}
+	}
+	if assertion.Operator == "" {
+		return nil, fmt.Errorf("expected one of %s after %s, got %q", strings.Join(operators, " "), path, rest)
+	}
+
+	literal := strings.TrimSpace(rest[len(assertion.Operator):])
+	if err := json.Unmarshal([]byte(literal), &assertion.Value); err != nil {
+		return nil, fmt.Errorf("value %q is not a JSON literal such as 5, \"UP\" or true", literal)
+	}
+	if _, ok := assertion.Value.(float64); !ok && assertion.Operator != "==" && assertion.Operator != "!=" {
+		return nil, fmt.Errorf("operator %s needs a number, got %s", assertion.Operator, literal)
+	}
+	return assertion, nil
+}
+
+// Check evaluates the assertion against a decoded JSON document, returning
+// a readable error if it doesn't hold
+func (a *Assertion) Check(doc any) error {
+	actual, ok := a.Path.Lookup(doc)
+	if !ok {
+		return fmt.Errorf("%s is missing", a.Path)
+	}
+	if a.Operator == "" {
+		return nil
+	}
+
+	var holds bool
+	switch a.Operator {
+	case "==":
+		holds = reflect.DeepEqual(actual, a.Value)
+	case "!=":
+		holds = !reflect.DeepEqual(actual, a.Value)
+	default:
+		number, ok := actual.(float64)
+		if !ok {
+			return fmt.Errorf("%s is %s, not a number", a.Path, format(actual))
+		}
+		want := a.Value.(float64)
+		switch a.Operator {
+		case "<":
+			holds = number < want
+		case "<=":
+			holds = number <= want
+		case ">":
+			holds = number > want
+		case ">=":
+			holds = number >= want
+		}
+	}
+
+	if !holds {
+		return fmt.Errorf("%s is %s, want %s %s", a.Path, format(actual), a.Operator, format(a.Value))
+	}
+	return nil
+}
+
+// format renders a JSON value as it would appear in the document
+func format(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
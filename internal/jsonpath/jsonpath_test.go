@@ -0,0 +1,86 @@
+package jsonpath
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const testDoc = `{
+	"status": "UP",
+	"db": {"replicationLag": 3, "primary": true},
+	"queues": [{"name": "mail", "depth": 120}],
+	"build info": {"version": "1.4.0"}
+}`
+
+func decode(t *testing.T) any {
+	t.Helper()
+	var doc any
+	if err := json.Unmarshal([]byte(testDoc), &doc); err != nil {
+		t.Fatalf("failed to decode test document: %v", err)
+	}
+	return doc
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		expr    string
+		wantErr string
+	}{
+		{expr: `$.db.replicationLag < 5`},
+		{expr: `$.db.replicationLag<5`},
+		{expr: `$.db.replicationLag >= 5`, wantErr: "$.db.replicationLag is 3, want >= 5"},
+		{expr: `$.status == "UP"`},
+		{expr: `$.status != "UP"`, wantErr: `$.status is "UP", want != "UP"`},
+		{expr: `$.db.primary == true`},
+		{expr: `$.queues[0].depth > 100`},
+		{expr: `$.queues[0].depth <= 100`, wantErr: "is 120, want <= 100"},
+		{expr: `$["build info"].version == "1.4.0"`},
+		{expr: `$.db`},
+		{expr: `$.cache`, wantErr: "$.cache is missing"},
+		{expr: `$.queues[3].depth < 10`, wantErr: "is missing"},
+		{expr: `$.status > 1`, wantErr: `$.status is "UP", not a number`},
+	}
+
+	doc := decode(t)
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			assertion, err := ParseAssertion(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseAssertion() error = %v", err)
+			}
+
+			err = assertion.Check(doc)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("Check() error = %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("Check() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseAssertionErrors(t *testing.T) {
+	tests := []struct {
+		expr    string
+		wantErr string
+	}{
+		{expr: `db.replicationLag < 5`, wantErr: "must start with $"},
+		{expr: `$..lag`, wantErr: "empty key"},
+		{expr: `$.queues[0`, wantErr: "unclosed ["},
+		{expr: `$.queues[-1]`, wantErr: "invalid index"},
+		{expr: `$.lag ~ 5`, wantErr: "expected one of"},
+		{expr: `$.status == UP`, wantErr: "not a JSON literal"},
+		{expr: `$.status < "UP"`, wantErr: "needs a number"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := ParseAssertion(tt.expr)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseAssertion() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
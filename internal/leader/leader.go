@@ -0,0 +1,178 @@
+package leader
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/storage"
+)
+
+// LeaseName is the lease replicas of cmd/server compete for
+const LeaseName = "scheduler"
+
+// releaseTimeout bounds giving up the lease on shutdown
+const releaseTimeout = 5 * time.Second
+
+// Elector campaigns for a lease so only one of several replicas leads
+type Elector struct {
+	leases storage.Leases
+	name   string
+	holder string
+	ttl    time.Duration
+}
+
+// NewElector creates an elector competing for the named lease as holder.
+// A leader renews its lease every third of ttl, so a replica that stops
+// renewing is replaced within about ttl.
+func NewElector(leases storage.Leases, name, holder string, ttl time.Duration) *Elector {
+	return &Elector{
+		leases: leases,
+		name:   name,
+		holder: holder,
+		ttl:    ttl,
+	}
+}
+
+// ErrLeaseLost is the cause lead's context is cancelled with when the lease
+// is lost, as opposed to Run's context being done
+var ErrLeaseLost = errors.New("lease lost")
+
+// Run campaigns until ctx is done, calling lead while this replica holds
+// the lease. lead's context is cancelled with ErrLeaseLost when the lease is
+// lost; Run keeps renewing meanwhile, and only leads again once lead has
+// returned. On shutdown Run waits for lead to return, then releases the
+// lease so another replica takes over without waiting for it to expire.
+func (e *Elector) Run(ctx context.Context, lead func(ctx context.Context)) {
+	interval := e.ttl / 3
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	// The lease is still ours until it expires; a leader that hasn't
+	// renewed it steps down just before another replica could take it,
+	// even while a renewal is still pending
+	expiry := time.NewTimer(e.ttl)
+	expiry.Stop()
+	defer expiry.Stop()
+
+	var (
+		renewals = make(chan renewal, 1)
+		pending  bool
+		expired  <-chan time.Time // nil unless the lease is held
+		stop     context.CancelCauseFunc
+		done     <-chan struct{} // closed once lead returns; nil once observed
+	)
+	renew := func() {
+		pending = true
+		go func() {
+			renewCtx, cancel := context.WithTimeout(ctx, interval)
+			defer cancel()
+			started := time.Now()
+			acquired, err := e.leases.AcquireLease(renewCtx, e.name, e.holder, e.ttl)
+			renewals <- renewal{acquired: acquired, err: err, started: started}
+		}()
+	}
+	// lead starts once the lease is held and the previous lead has returned
+	startLeading := func() {
+		if expired == nil || done != nil {
+			return
+		}
+		log.Printf("Acquired lease %s as %s, leading", e.name, e.holder)
+		var leadCtx context.Context
+		leadCtx, stop = context.WithCancelCause(ctx)
+		finished := make(chan struct{})
+		done = finished
+		go func() {
+			defer close(finished)
+			lead(leadCtx)
+		}()
+	}
+	// stepDown cancels lead without waiting for it to return
+	stepDown := func() {
+		expired = nil
+		if stop != nil {
+			stop(ErrLeaseLost)
+			stop = nil
+		}
+	}
+
+	renew()
+	for {
+		select {
+		case <-ctx.Done():
+			if pending {
+				<-renewals
+			}
+			if done != nil {
+				<-done
+			}
+			if expired != nil {
+				e.release()
+			}
+			return
+		case <-done:
+			// lead having returned after stepping down, lead again if the
+			// lease has been won back meanwhile
+			done = nil
+			if stop == nil {
+				startLeading()
+			}
+		case r := <-renewals:
+			pending = false
+			switch {
+			case ctx.Err() != nil:
+				// Shutting down; handled above
+			case r.err != nil:
+				log.Printf("Failed to renew lease %s: %v", e.name, r.err)
+			case r.acquired:
+				// The lease runs from when the renewal was made, not
+				// from when it was answered
+				resetTimer(expiry, time.Until(r.started.Add(e.ttl-interval)))
+				expired = expiry.C
+				startLeading()
+			case expired != nil:
+				log.Printf("Lease %s was taken by another replica, stepping down", e.name)
+				stepDown()
+			}
+		case <-expired:
+			log.Printf("Lost lease %s, stepping down", e.name)
+			stepDown()
+		case <-ticker.C:
+			// A renewal still pending after a whole interval has timed out
+			// and is about to report it
+			if !pending {
+				renew()
+			}
+		}
+	}
+}
+
+// renewal is the outcome of one attempt to acquire or renew the lease
+type renewal struct {
+	acquired bool
+	err      error
+	started  time.Time
+}
+
+// resetTimer makes timer fire after d, discarding a tick it already fired
+func resetTimer(timer *time.Timer, d time.Duration) {
+	if !timer.Stop() {
+		select {
+		case <-timer.C:
+		default:
+		}
+	}
+	timer.Reset(d)
+}
+
+// release gives up the lease after leading has stopped
+func (e *Elector) release() {
+	ctx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+	defer cancel()
+	if err := e.leases.ReleaseLease(ctx, e.name, e.holder); err != nil {
+		log.Printf("Failed to release lease %s: %v", e.name, err)
+		return
+	}
+	log.Printf("Released lease %s", e.name)
+}
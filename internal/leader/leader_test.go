@@ -0,0 +1,224 @@
+package leader
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/storage"
+)
+
+const testTTL = 90 * time.Millisecond
+
+// replica runs an elector in the background, tracking whether it leads
+type replica struct {
+	leading atomic.Bool
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+func startReplica(t *testing.T, leases storage.Leases, holder string, leaders *atomic.Int32) *replica {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &replica{cancel: cancel, done: make(chan struct{})}
+
+	go func() {
+		defer close(r.done)
+		NewElector(leases, LeaseName, holder, testTTL).Run(ctx, func(ctx context.Context) {
+			if leaders.Add(1) > 1 {
+				t.Errorf("%s leads alongside another replica", holder)
+			}
+			r.leading.Store(true)
+			<-ctx.Done()
+			r.leading.Store(false)
+			leaders.Add(-1)
+		})
+	}()
+	t.Cleanup(r.stop)
+	return r
+}
+
+func (r *replica) stop() {
+	r.cancel()
+	<-r.done
+}
+
+// waitFor polls cond until it holds or the timeout passes
+func waitFor(t *testing.T, timeout time.Duration, cond func() bool) bool {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return true
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	return cond()
+}
+
+func TestElectorFailoverOnShutdown(t *testing.T) {
+	leases := storage.NewMemoryBackend()
+	var leaders atomic.Int32
+
+	first := startReplica(t, leases, "first", &leaders)
+	if !waitFor(t, time.Second, first.leading.Load) {
+		t.Fatal("first replica never led")
+	}
+
+	second := startReplica(t, leases, "second", &leaders)
+	time.Sleep(2 * testTTL)
+	if second.leading.Load() {
+		t.Fatal("second replica leads while the first holds the lease")
+	}
+
+	// The released lease is taken on the second replica's next attempt
+	first.stop()
+	if !waitFor(t, testTTL/3+50*time.Millisecond, second.leading.Load) {
+		t.Error("second replica didn't take over after the first shut down")
+	}
+}
+
+func TestElectorFailoverOnExpiry(t *testing.T) {
+	leases := storage.NewMemoryBackend()
+
+	// A leader that crashed leaves its lease behind without renewing it
+	if ok, _ := leases.AcquireLease(context.Background(), LeaseName, "crashed", testTTL); !ok {
+		t.Fatal("AcquireLease() failed")
+	}
+
+	var leaders atomic.Int32
+	start := time.Now()
+	r := startReplica(t, leases, "standby", &leaders)
+	if !waitFor(t, 2*testTTL, r.leading.Load) {
+		t.Fatal("standby never took over the expired lease")
+	}
+	if elapsed := time.Since(start); elapsed < testTTL-10*time.Millisecond {
+		t.Errorf("standby took over after %s, before the lease expired", elapsed)
+	}
+}
+
+// flakyLeases fails every renewal once failing is set. Once hang is set,
+// renewals block until it's closed, ignoring their context.
+type flakyLeases struct {
+	storage.Leases
+	mu      sync.Mutex
+	failing bool
+	hang    chan struct{}
+}
+
+func (f *flakyLeases) AcquireLease(ctx context.Context, name, holder string, ttl time.Duration) (bool, error) {
+	f.mu.Lock()
+	failing, hang := f.failing, f.hang
+	f.mu.Unlock()
+	if hang != nil {
+		<-hang
+		return false, context.DeadlineExceeded
+	}
+	if failing {
+		return false, context.DeadlineExceeded
+	}
+	return f.Leases.AcquireLease(ctx, name, holder, ttl)
+}
+
+func TestElectorStepsDownWhenRenewalsFail(t *testing.T) {
+	leases := &flakyLeases{Leases: storage.NewMemoryBackend()}
+	var leaders atomic.Int32
+
+	r := startReplica(t, leases, "leader", &leaders)
+	if !waitFor(t, time.Second, r.leading.Load) {
+		t.Fatal("replica never led")
+	}
+
+	leases.mu.Lock()
+	leases.failing = true
+	leases.mu.Unlock()
+
+	// Leadership is kept through a failed renewal, but not past the lease
+	time.Sleep(testTTL / 3)
+	if !r.leading.Load() {
+		t.Error("replica stepped down after a single failed renewal")
+	}
+	if !waitFor(t, testTTL, func() bool { return !r.leading.Load() }) {
+		t.Error("replica kept leading after its lease expired")
+	}
+}
+
+func TestElectorStepsDownWhileRenewalHangs(t *testing.T) {
+	leases := &flakyLeases{Leases: storage.NewMemoryBackend()}
+	var leaders atomic.Int32
+
+	r := startReplica(t, leases, "leader", &leaders)
+	if !waitFor(t, time.Second, r.leading.Load) {
+		t.Fatal("replica never led")
+	}
+
+	hang := make(chan struct{})
+	leases.mu.Lock()
+	leases.hang = hang
+	leases.mu.Unlock()
+	// Cleanups run last first, so the renewal returns before the replica stops
+	t.Cleanup(func() { close(hang) })
+
+	if !waitFor(t, testTTL, func() bool { return !r.leading.Load() }) {
+		t.Error("replica kept leading past its lease while a renewal hung")
+	}
+}
+
+func TestElectorDoesNotWaitForLeadToStepDown(t *testing.T) {
+	leases := &flakyLeases{Leases: storage.NewMemoryBackend()}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		leads   atomic.Int32
+		running atomic.Int32
+		causes  = make(chan error, 4)
+	)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		NewElector(leases, LeaseName, "leader", testTTL).Run(ctx, func(ctx context.Context) {
+			leads.Add(1)
+			if running.Add(1) > 1 {
+				t.Error("lead runs twice at once")
+			}
+			<-ctx.Done()
+			causes <- context.Cause(ctx)
+			// Checks in flight take a while to wind down
+			time.Sleep(testTTL)
+			running.Add(-1)
+		})
+	}()
+
+	if !waitFor(t, time.Second, func() bool { return leads.Load() == 1 }) {
+		t.Fatal("replica never led")
+	}
+	leases.mu.Lock()
+	leases.failing = true
+	leases.mu.Unlock()
+
+	select {
+	case cause := <-causes:
+		if cause != ErrLeaseLost {
+			t.Errorf("lead's context cause = %v, want ErrLeaseLost", cause)
+		}
+	case <-time.After(2 * testTTL):
+		t.Fatal("lead wasn't cancelled after the lease was lost")
+	}
+
+	// Renewals go on while lead winds down, and leading resumes after it
+	leases.mu.Lock()
+	leases.failing = false
+	leases.mu.Unlock()
+	if !waitFor(t, 3*testTTL, func() bool { return leads.Load() == 2 }) {
+		t.Error("replica didn't lead again after winning the lease back")
+	}
+
+	cancel()
+	<-done
+	if cause := <-causes; cause == ErrLeaseLost {
+		t.Error("lead's context was cancelled with ErrLeaseLost on shutdown")
+	}
+}
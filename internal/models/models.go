@@ -2,6 +2,19 @@ package models
 
 import "time"
 
+// Component statuses reported by the monitoring endpoint
+const (
+	StatusOperational = "OPERATIONAL"
+	StatusDegraded    = "DEGRADED"
+	StatusDown        = "DOWN"
+)
+
+// Rollup granularities
+const (
+	GranularityHour = "hour"
+	GranularityDay  = "day"
+)
+
 // HealthStatusResponse represents the JSON response from the monitoring endpoint
 type HealthStatusResponse struct {
 	Timestamp     string      `json:"timestamp"`
@@ -11,23 +24,102 @@ type HealthStatusResponse struct {
 
 // Component represents a single component in the health status response
 type Component struct {
-	Name           string  `json:"name"`
-	Status         string  `json:"status"`
-	ResponseTimeMs float64 `json:"responseTimeMs"`
+	Name           string       `json:"name"`
+	Status         string       `json:"status"`
+	ResponseTimeMs float64      `json:"responseTimeMs"`
+	Message        string       `json:"message,omitempty"`    // why the component isn't operational
+	ErrorClass     string       `json:"errorClass,omitempty"` // kind of failure when the check errored
+	Certificate    *Certificate `json:"certificate,omitempty"`
+	Steps          []StepResult `json:"steps,omitempty"`
+	// Failures counts the consecutive checks the component has been DOWN,
+	// up to and including this one
+	Failures int `json:"-"`
+}
+
+// StepResult is the outcome of one step of a synthetic check
+type StepResult struct {
+	Name       string  `json:"name"`
+	Status     string  `json:"status"`
+	DurationMs float64 `json:"durationMs"`
+	Message    string  `json:"message,omitempty"`
+	ErrorClass string  `json:"errorClass,omitempty"` // set when the request itself failed
+}
+
+// Attempt is the outcome of one try of a check that was retried
+type Attempt struct {
+	Status     string  `json:"status"`
+	DurationMs float64 `json:"durationMs"`
+	Message    string  `json:"message,omitempty"`
+}
+
+// Certificate describes the TLS certificate chain served by a component
+type Certificate struct {
+	ExpiresAt     time.Time `json:"expiresAt"` // earliest expiry in the chain
+	DaysRemaining int       `json:"daysRemaining"`
+	Issuer        string    `json:"issuer"`
+	SANs          []string  `json:"sans"`
 }
 
 // MonitoringResult represents the result of a monitoring check
 type MonitoringResult struct {
-	Components           []Component
-	TotalResponseTimeMs  int64
+	Target              string
+	Components          []Component
+	TotalResponseTimeMs int64
 	Timestamp           time.Time
+	Attempts            []Attempt // every try, when the check was retried
+}
+
+// CheckRecord represents a single stored component check
+type CheckRecord struct {
+	ServiceName            string       `json:"serviceName"`
+	Status                 string       `json:"status"`
+	InternalResponseTimeMs float64      `json:"internalResponseTimeMs"`
+	TotalResponseTimeMs    int64        `json:"totalResponseTimeMs"`
+	Message                string       `json:"message,omitempty"`
+	ErrorClass             string       `json:"errorClass,omitempty"`
+	Certificate            *Certificate `json:"certificate,omitempty"`
+	Steps                  []StepResult `json:"steps,omitempty"`
+	Attempts               []Attempt    `json:"attempts,omitempty"`
+	Failures               int          `json:"failures,omitempty"` // consecutive DOWN checks
+	StatusSince            time.Time    `json:"statusSince"`        // start of the current outage, or of the operational spell
+	LastChecked            time.Time    `json:"lastChecked"`
+	ExpiresAt              time.Time    `json:"expiresAt"` // zero means the record never expires
+}
+
+// Rollup summarises the checks of one component over an hour or a day
+type Rollup struct {
+	ServiceName       string    `json:"serviceName"`
+	Granularity       string    `json:"granularity"`
+	Start             time.Time `json:"start"`
+	Checks            int       `json:"checks"`
+	Operational       int       `json:"operational"`
+	WorstStatus       string    `json:"worstStatus"`
+	MinResponseTimeMs float64   `json:"minResponseTimeMs"`
+	AvgResponseTimeMs float64   `json:"avgResponseTimeMs"`
+	MaxResponseTimeMs float64   `json:"maxResponseTimeMs"`
+	P95ResponseTimeMs float64   `json:"p95ResponseTimeMs"`
+	ExpiresAt         time.Time `json:"expiresAt"`
+}
+
+// StatusSeverity ranks a status so the worst of several can be picked.
+// Statuses other than the known ones rank between DEGRADED and DOWN.
+func StatusSeverity(status string) int {
+	switch status {
+	case StatusOperational:
+		return 0
+	case StatusDegraded:
+		return 1
+	case StatusDown:
+		return 3
+	default:
+		return 2
+	}
 }
 
-// DynamoDBItem represents an item to be stored in DynamoDB
-type DynamoDBItem struct {
-	ServiceName             string    `dynamodbav:"serviceName"`
-	Status                  string    `dynamodbav:"status"`
-	InternalResponseTimeMs  float64   `dynamodbav:"internalResponseTimeMs"`
-	TotalResponseTimeMs     int64     `dynamodbav:"totalResponseTimeMs"`
-	LastChecked             string    `dynamodbav:"lastChecked"`
+// WorseStatus returns whichever of two statuses is more severe
+func WorseStatus(a, b string) string {
+	if StatusSeverity(b) > StatusSeverity(a) {
+		return b
+	}
+	return a
 }
@@ -0,0 +1,40 @@
+package monitoring
+
+import (
+	"github.com/openlearnnitj/openlearn-monitoring/internal/config"
+	"github.com/openlearnnitj/openlearn-monitoring/internal/jsonpath"
+	"github.com/openlearnnitj/openlearn-monitoring/internal/models"
+)
+
+// applyAssertions checks assertions against the decoded response body. A
+// failing assertion raises the covered components to its severity and adds
+// the failure to their message. Components are matched by their name in the
+// response, before namespacing.
+func applyAssertions(assertions []config.Assertion, doc any, components []models.Component) {
+	for _, assertion := range assertions {
+		parsed, err := jsonpath.ParseAssertion(assertion.Expr)
+		if err == nil {
+			err = parsed.Check(doc)
+		}
+		if err == nil {
+			continue
+		}
+
+		for i := range components {
+			if assertion.Component != "" && components[i].Name != assertion.Component {
+				continue
+			}
+			degrade(&components[i], assertion.Severity, err.Error())
+		}
+	}
+}
+
+// degrade raises a component to status, unless it's already worse, and
+// records the reason in its message
+func degrade(component *models.Component, status, reason string) {
+	component.Status = models.WorseStatus(component.Status, status)
+	if component.Message != "" {
+		component.Message += "; "
+	}
+	component.Message += reason
+}
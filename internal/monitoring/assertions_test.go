@@ -0,0 +1,138 @@
+package monitoring
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/config"
+	"github.com/openlearnnitj/openlearn-monitoring/internal/models"
+)
+
+const richHealthBody = `{
+	"overallStatus": "OPERATIONAL",
+	"components": [
+		{"name": "Database", "status": "OPERATIONAL", "responseTimeMs": 12.5},
+		{"name": "Redis", "status": "OPERATIONAL", "responseTimeMs": 3}
+	],
+	"db": {"replicationLag": 7},
+	"queue": {"depth": 10}
+}`
+
+func TestCheckHealthAssertions(t *testing.T) {
+	server := newHealthServer(t, http.StatusOK, richHealthBody)
+
+	tests := []struct {
+		name        string
+		format      string
+		assertions  []config.Assertion
+		want        map[string]string
+		wantMessage map[string]string
+	}{
+		{
+			name:   "passing assertions keep components operational",
+			format: config.FormatComponents,
+			assertions: []config.Assertion{
+				{Expr: "$.queue.depth < 100", Severity: models.StatusDown},
+			},
+			want: map[string]string{"api/Database": models.StatusOperational, "api/Redis": models.StatusOperational, "api/target": models.StatusOperational},
+		},
+		{
+			name:   "failing assertion scoped to one component",
+			format: config.FormatComponents,
+			assertions: []config.Assertion{
+				{Expr: "$.db.replicationLag < 5", Severity: models.StatusDegraded, Component: "Database"},
+			},
+			want:        map[string]string{"api/Database": models.StatusDegraded, "api/Redis": models.StatusOperational, "api/target": models.StatusOperational},
+			wantMessage: map[string]string{"api/Database": "$.db.replicationLag is 7, want < 5"},
+		},
+		{
+			name:   "unscoped assertion covers every component",
+			format: config.FormatComponents,
+			assertions: []config.Assertion{
+				{Expr: "$.cache", Severity: models.StatusDown},
+			},
+			want:        map[string]string{"api/Database": models.StatusDown, "api/Redis": models.StatusDown, "api/target": models.StatusOperational},
+			wantMessage: map[string]string{"api/Database": "$.cache is missing", "api/Redis": "$.cache is missing"},
+		},
+		{
+			name:   "failures are combined and the worst severity wins",
+			format: config.FormatOverall,
+			assertions: []config.Assertion{
+				{Expr: "$.db.replicationLag < 5", Severity: models.StatusDegraded},
+				{Expr: "$.queue.depth < 5", Severity: models.StatusDown},
+			},
+			want:        map[string]string{"api": models.StatusDown},
+			wantMessage: map[string]string{"api": "$.db.replicationLag is 7, want < 5; $.queue.depth is 10, want < 5"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := target("api", server.URL, tt.format)
+			target.Assertions = tt.assertions
+
+			result, err := NewService(nil).CheckHealth(context.Background(), target)
+			if err != nil {
+				t.Fatalf("CheckHealth() error = %v", err)
+			}
+			if len(result.Components) != len(tt.want) {
+				t.Fatalf("got %d components, want %d", len(result.Components), len(tt.want))
+			}
+			for _, component := range result.Components {
+				if component.Status != tt.want[component.Name] {
+					t.Errorf("%s status = %s, want %s", component.Name, component.Status, tt.want[component.Name])
+				}
+				if component.Message != tt.wantMessage[component.Name] {
+					t.Errorf("%s message = %q, want %q", component.Name, component.Message, tt.wantMessage[component.Name])
+				}
+			}
+		})
+	}
+}
+
+func TestCheckHTTPAssertions(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		want        string
+		wantMessage string
+	}{
+		{
+			name: "assertion holds",
+			body: `{"status": "UP"}`,
+			want: models.StatusOperational,
+		},
+		{
+			name:        "assertion fails",
+			body:        `{"status": "MAINTENANCE"}`,
+			want:        models.StatusDegraded,
+			wantMessage: `$.status is "MAINTENANCE", want == "UP"`,
+		},
+		{
+			name:        "body is not json",
+			body:        `<html>`,
+			want:        models.StatusDown,
+			wantMessage: "response body is not valid JSON",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newHealthServer(t, http.StatusOK, tt.body)
+
+			target := httpTarget(server.URL)
+			target.Headers = map[string]string{"X-API-Secret": "secret"}
+			target.Assertions = []config.Assertion{{Expr: `$.status == "UP"`, Severity: models.StatusDegraded}}
+
+			result, err := NewService(nil).CheckHealth(context.Background(), target)
+			if err != nil {
+				t.Fatalf("CheckHealth() error = %v", err)
+			}
+			component := result.Components[0]
+			if component.Status != tt.want || component.Message != tt.wantMessage {
+				t.Errorf("component = %s %q, want %s %q", component.Status, component.Message, tt.want, tt.wantMessage)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package monitoring
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/config"
+	"github.com/openlearnnitj/openlearn-monitoring/internal/models"
+)
+
+// checkDNS resolves the target's hostname and checks that every expected
+// value is among the records returned. Resolution time is recorded as the
+// component's response time, so DNS outages show up separately from the
+// applications behind them.
+func (s *Service) checkDNS(ctx context.Context, target config.Target) (*models.MonitoringResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(target.Timeout))
+	defer cancel()
+
+	resolver := net.DefaultResolver
+	if target.Resolver != "" {
+		resolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, network, target.Resolver)
+			},
+		}
+	}
+
+	component := models.Component{Name: target.Name, Status: models.StatusOperational}
+
+	start := time.Now()
+	values, err := lookup(ctx, resolver, target.RecordType, target.Hostname)
+	elapsed := time.Since(start)
+
+	switch {
+	case err != nil:
+		degrade(&component, models.StatusDown, fmt.Sprintf("%s lookup of %s failed: %v", target.RecordType, target.Hostname, err))
+		component.ErrorClass = errorClass(err)
+	case len(values) == 0:
+		degrade(&component, models.StatusDown, fmt.Sprintf("no %s records for %s", target.RecordType, target.Hostname))
+	default:
+		var missing []string
+		for _, expected := range target.Expect {
+			if !slices.Contains(values, normalizeRecord(target.RecordType, expected)) {
+				missing = append(missing, expected)
+			}
+		}
+		if len(missing) > 0 {
+			degrade(&component, models.StatusDown, fmt.Sprintf("%s records of %s are missing %s, got %s",
+				target.RecordType, target.Hostname, strings.Join(missing, ", "), strings.Join(values, ", ")))
+		}
+	}
+	if err == nil && target.MaxLatency > 0 && elapsed > time.Duration(target.MaxLatency) {
+		degrade(&component, models.StatusDegraded, fmt.Sprintf("resolution took %s, above the %s limit", elapsed.Round(time.Millisecond), time.Duration(target.MaxLatency)))
+	}
+	if component.Message != "" {
+		log.Printf("Target %s is %s: %s", target.Name, component.Status, component.Message)
+	}
+	component.ResponseTimeMs = float64(elapsed.Milliseconds())
+
+	return &models.MonitoringResult{
+		Target:              target.Name,
+		Components:          []models.Component{component},
+		TotalResponseTimeMs: elapsed.Milliseconds(),
+		Timestamp:           time.Now().UTC(),
+	}, nil
+}
+
+// lookup resolves records of the given type, normalized for comparison
+func lookup(ctx context.Context, resolver *net.Resolver, recordType, hostname string) ([]string, error) {
+	var values []string
+	switch recordType {
+	case "A", "AAAA":
+		network := "ip4"
+		if recordType == "AAAA" {
+			network = "ip6"
+		}
+		ips, err := resolver.LookupIP(ctx, network, hostname)
+		if err != nil {
+			return nil, err
+		}
+		for _, ip := range ips {
+			values = append(values, ip.String())
+		}
+	case "CNAME":
+		cname, err := resolver.LookupCNAME(ctx, hostname)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, cname)
+	case "TXT":
+		records, err := resolver.LookupTXT(ctx, hostname)
+		if err != nil {
+			return nil, err
+		}
+		values = records
+	case "MX":
+		records, err := resolver.LookupMX(ctx, hostname)
+		if err != nil {
+			return nil, err
+		}
+		for _, mx := range records {
+			values = append(values, mx.Host)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported record type %q", recordType)
+	}
+
+	for i, value := range values {
+		values[i] = normalizeRecord(recordType, value)
+	}
+	return values, nil
+}
+
+// normalizeRecord puts a record value in canonical form: IPs in their
+// shortest form and host names lowercased without the trailing dot
+func normalizeRecord(recordType, value string) string {
+	switch recordType {
+	case "A", "AAAA":
+		if ip := net.ParseIP(value); ip != nil {
+			return ip.String()
+		}
+	case "CNAME", "MX":
+		return strings.ToLower(strings.TrimSuffix(value, "."))
+	}
+	return value
+}
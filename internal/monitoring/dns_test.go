@@ -0,0 +1,173 @@
+package monitoring
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/config"
+	"github.com/openlearnnitj/openlearn-monitoring/internal/models"
+)
+
+// DNS record types used by the test server
+const (
+	typeA     = 1
+	typeCNAME = 5
+	typeMX    = 15
+	typeTXT   = 16
+	typeAAAA  = 28
+)
+
+// testZone maps a lowercased name and record type to the answers' rdata
+var testZone = map[string]map[uint16][][]byte{
+	"openlearn.test.": {
+		typeA:    {net.ParseIP("192.0.2.10").To4(), net.ParseIP("192.0.2.11").To4()},
+		typeAAAA: {net.ParseIP("2001:db8::10")},
+		typeTXT:  {txtData("v=spf1 -all")},
+		typeMX:   {append([]byte{0, 10}, encodeName("mail.openlearn.test.")...)},
+	},
+	"www.openlearn.test.": {
+		typeCNAME: {encodeName("openlearn.test.")},
+	},
+}
+
+func encodeName(name string) []byte {
+	var data []byte
+	for _, label := range strings.Split(strings.TrimSuffix(name, "."), ".") {
+		data = append(data, byte(len(label)))
+		data = append(data, label...)
+	}
+	return append(data, 0)
+}
+
+func txtData(text string) []byte {
+	return append([]byte{byte(len(text))}, text...)
+}
+
+// newDNSServer answers UDP queries from testZone, with NXDOMAIN for names
+// it doesn't know
+func newDNSServer(t *testing.T) string {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if response := answer(buf[:n]); response != nil {
+				conn.WriteTo(response, addr)
+			}
+		}
+	}()
+	return conn.LocalAddr().String()
+}
+
+// answer builds the response to a single-question query
+func answer(query []byte) []byte {
+	if len(query) < 12 {
+		return nil
+	}
+
+	// Read the question's name and type
+	var labels []string
+	offset := 12
+	for offset < len(query) && query[offset] != 0 {
+		length := int(query[offset])
+		if offset+1+length > len(query) {
+			return nil
+		}
+		labels = append(labels, string(query[offset+1:offset+1+length]))
+		offset += 1 + length
+	}
+	if offset+5 > len(query) {
+		return nil
+	}
+	name := strings.ToLower(strings.Join(labels, ".")) + "."
+	qtype := binary.BigEndian.Uint16(query[offset+1:])
+	question := query[12 : offset+5]
+
+	records, known := testZone[name]
+	rdatas := records[qtype]
+
+	// Header: same ID, response with AA, RD and RA set
+	response := make([]byte, 12)
+	copy(response, query[:2])
+	flags := uint16(0x8580)
+	if !known {
+		flags |= 3 // NXDOMAIN
+	}
+	binary.BigEndian.PutUint16(response[2:], flags)
+	binary.BigEndian.PutUint16(response[4:], 1)
+	binary.BigEndian.PutUint16(response[6:], uint16(len(rdatas)))
+	response = append(response, question...)
+
+	for _, rdata := range rdatas {
+		record := []byte{0xC0, 12} // pointer to the question's name
+		record = binary.BigEndian.AppendUint16(record, qtype)
+		record = binary.BigEndian.AppendUint16(record, 1) // IN
+		record = binary.BigEndian.AppendUint32(record, 60)
+		record = binary.BigEndian.AppendUint16(record, uint16(len(rdata)))
+		response = append(response, append(record, rdata...)...)
+	}
+	return response
+}
+
+func dnsTarget(resolver, hostname, recordType string, expect ...string) config.Target {
+	return config.Target{
+		Name:       "dns",
+		Kind:       config.KindDNS,
+		Hostname:   hostname,
+		RecordType: recordType,
+		Resolver:   resolver,
+		Expect:     expect,
+		Timeout:    config.Duration(time.Second),
+	}
+}
+
+func TestCheckDNS(t *testing.T) {
+	resolver := newDNSServer(t)
+
+	tests := []struct {
+		name        string
+		target      config.Target
+		want        string
+		wantMessage string
+		wantClass   string
+	}{
+		{"A records", dnsTarget(resolver, "openlearn.test", "A", "192.0.2.11"), models.StatusOperational, "", ""},
+		{"A record without expectations", dnsTarget(resolver, "openlearn.test", "A"), models.StatusOperational, "", ""},
+		{"missing A record", dnsTarget(resolver, "openlearn.test", "A", "192.0.2.99"), models.StatusDown, "missing 192.0.2.99, got 192.0.2.10, 192.0.2.11", ""},
+		{"AAAA record in any form", dnsTarget(resolver, "openlearn.test", "AAAA", "2001:0db8::0010"), models.StatusOperational, "", ""},
+		{"CNAME", dnsTarget(resolver, "www.openlearn.test", "CNAME", "OpenLearn.test."), models.StatusOperational, "", ""},
+		{"TXT", dnsTarget(resolver, "openlearn.test", "TXT", "v=spf1 -all"), models.StatusOperational, "", ""},
+		{"MX", dnsTarget(resolver, "openlearn.test", "MX", "mail.openlearn.test"), models.StatusOperational, "", ""},
+		{"unknown name", dnsTarget(resolver, "missing.openlearn.test", "A"), models.StatusDown, "lookup of missing.openlearn.test failed", ErrorDNS},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewService(nil).CheckHealth(context.Background(), tt.target)
+			if err != nil {
+				t.Fatalf("CheckHealth() error = %v", err)
+			}
+
+			component := result.Components[0]
+			if component.Status != tt.want || !strings.Contains(component.Message, tt.wantMessage) {
+				t.Errorf("component = %s %q, want %s %q", component.Status, component.Message, tt.want, tt.wantMessage)
+			}
+			if component.ErrorClass != tt.wantClass {
+				t.Errorf("ErrorClass = %q, want %q", component.ErrorClass, tt.wantClass)
+			}
+		})
+	}
+}
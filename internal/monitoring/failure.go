@@ -0,0 +1,102 @@
+package monitoring
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/config"
+	"github.com/openlearnnitj/openlearn-monitoring/internal/models"
+)
+
+// TargetComponent names the component recorded for a health endpoint that
+// couldn't be checked, under the target's namespace ("api/target")
+const TargetComponent = "target"
+
+// Classes of failed checks, stored with the DOWN component
+const (
+	ErrorTimeout    = "timeout"    // no response within the target's timeout
+	ErrorDNS        = "dns"        // the host couldn't be resolved
+	ErrorConnection = "connection" // the connection was refused or reset
+	ErrorTLS        = "tls"        // the TLS handshake or certificate was rejected
+	ErrorStatus     = "status"     // an unexpected HTTP status code
+	ErrorResponse   = "response"   // the response couldn't be read or was invalid
+	ErrorUnknown    = "error"
+)
+
+// classifiedError is a failure whose class is known where it happened
+type classifiedError struct {
+	class string
+	err   error
+}
+
+func (e *classifiedError) Error() string { return e.err.Error() }
+func (e *classifiedError) Unwrap() error { return e.err }
+
+// classify attaches a class to an error
+func classify(class string, err error) error {
+	return &classifiedError{class: class, err: err}
+}
+
+// errorClass tells what kind of failure err is
+func errorClass(err error) string {
+	var classified *classifiedError
+	if errors.As(err, &classified) {
+		return classified.class
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return ErrorTimeout
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return ErrorDNS
+	}
+
+	var (
+		verifyErr    *tls.CertificateVerificationError
+		recordErr    tls.RecordHeaderError
+		alertErr     tls.AlertError
+		authorityErr x509.UnknownAuthorityError
+		hostnameErr  x509.HostnameError
+		invalidErr   x509.CertificateInvalidError
+	)
+	if errors.As(err, &verifyErr) || errors.As(err, &recordErr) || errors.As(err, &alertErr) ||
+		errors.As(err, &authorityErr) || errors.As(err, &hostnameErr) || errors.As(err, &invalidErr) {
+		return ErrorTLS
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return ErrorConnection
+	}
+
+	return ErrorUnknown
+}
+
+// FailedResult records a check that returned an error as a DOWN component,
+// so the outage is stored and counted against uptime. Targets recorded as a
+// single component keep its name; health endpoints reporting components get
+// a TargetComponent.
+func FailedResult(target config.Target, err error) *models.MonitoringResult {
+	name := target.Name
+	if (target.Kind == "" || target.Kind == config.KindHealth) && target.Format != config.FormatOverall {
+		name = QualifiedName(target.Name, TargetComponent)
+	}
+
+	return &models.MonitoringResult{
+		Target: target.Name,
+		Components: []models.Component{{
+			Name:       name,
+			Status:     models.StatusDown,
+			Message:    err.Error(),
+			ErrorClass: errorClass(err),
+		}},
+		Timestamp: time.Now().UTC(),
+	}
+}
@@ -0,0 +1,58 @@
+package monitoring
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/config"
+)
+
+func TestFailedResult(t *testing.T) {
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	t.Cleanup(slow.Close)
+
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	untrusted := httptest.NewTLSServer(http.NotFoundHandler())
+	t.Cleanup(untrusted.Close)
+
+	tests := []struct {
+		name      string
+		target    config.Target
+		wantClass string
+	}{
+		{"timeout", target("api", slow.URL, config.FormatComponents), ErrorTimeout},
+		{"connection refused", target("api", closed.URL, config.FormatComponents), ErrorConnection},
+		{"untrusted certificate", target("api", untrusted.URL, config.FormatComponents), ErrorTLS},
+		{"unexpected status", target("api", newHealthServer(t, http.StatusBadGateway, "").URL, config.FormatComponents), ErrorStatus},
+		{"invalid json", target("api", newHealthServer(t, http.StatusOK, "<html>").URL, config.FormatComponents), ErrorResponse},
+	}
+	tests[0].target.Timeout = config.Duration(50 * time.Millisecond)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewService(nil).CheckHealth(context.Background(), tt.target)
+			if err == nil {
+				t.Fatal("CheckHealth() should fail")
+			}
+
+			result := FailedResult(tt.target, err)
+			if len(result.Components) != 1 {
+				t.Fatalf("got %d components, want 1", len(result.Components))
+			}
+			component := result.Components[0]
+			if component.Name != "api/target" || component.Status != "DOWN" || component.Message != err.Error() {
+				t.Errorf("component = %+v", component)
+			}
+			if component.ErrorClass != tt.wantClass {
+				t.Errorf("ErrorClass = %q, want %q", component.ErrorClass, tt.wantClass)
+			}
+		})
+	}
+}
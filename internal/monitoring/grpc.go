@@ -0,0 +1,105 @@
+package monitoring
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/config"
+	"github.com/openlearnnitj/openlearn-monitoring/internal/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// servingStatuses maps the gRPC health protocol onto component statuses
+var servingStatuses = map[healthpb.HealthCheckResponse_ServingStatus]string{
+	healthpb.HealthCheckResponse_SERVING:     models.StatusOperational,
+	healthpb.HealthCheckResponse_NOT_SERVING: models.StatusDown,
+	healthpb.HealthCheckResponse_UNKNOWN:     models.StatusDegraded,
+}
+
+// checkGRPC calls grpc.health.v1.Health/Check on the target's server. The
+// auth header and custom headers are sent as request metadata.
+func (s *Service) checkGRPC(ctx context.Context, target config.Target) (*models.MonitoringResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(target.Timeout))
+	defer cancel()
+
+	creds := insecure.NewCredentials()
+	if target.TLS {
+		creds = credentials.NewTLS(&tls.Config{
+			ServerName:         target.ServerName,
+			InsecureSkipVerify: target.TLSSkipVerify,
+		})
+	}
+
+	conn, err := grpc.NewClient(target.Address, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client: %w", err)
+	}
+	defer conn.Close()
+
+	if target.AuthValue != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, strings.ToLower(target.AuthHeader), target.AuthValue)
+	}
+	for key, value := range target.Headers {
+		ctx = metadata.AppendToOutgoingContext(ctx, strings.ToLower(key), value)
+	}
+
+	component := models.Component{Name: target.Name, Status: models.StatusOperational}
+
+	start := time.Now()
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: target.Service})
+	elapsed := time.Since(start)
+
+	switch {
+	case status.Code(err) == codes.NotFound:
+		degrade(&component, models.StatusDown, fmt.Sprintf("service %q is unknown to the health server", target.Service))
+		component.ErrorClass = ErrorResponse
+	case err != nil:
+		degrade(&component, models.StatusDown, fmt.Sprintf("health check failed: %v", err))
+		component.ErrorClass = grpcErrorClass(err)
+	default:
+		if mapped, ok := servingStatuses[resp.GetStatus()]; !ok {
+			degrade(&component, models.StatusDegraded, fmt.Sprintf("unexpected serving status %s", resp.GetStatus()))
+		} else if mapped != models.StatusOperational {
+			degrade(&component, mapped, fmt.Sprintf("serving status is %s", resp.GetStatus()))
+		}
+	}
+	if err == nil && target.MaxLatency > 0 && elapsed > time.Duration(target.MaxLatency) {
+		degrade(&component, models.StatusDegraded, fmt.Sprintf("health check took %s, above the %s limit", elapsed.Round(time.Millisecond), time.Duration(target.MaxLatency)))
+	}
+	if component.Message != "" {
+		log.Printf("Target %s is %s: %s", target.Name, component.Status, component.Message)
+	}
+	component.ResponseTimeMs = float64(elapsed.Milliseconds())
+
+	return &models.MonitoringResult{
+		Target:              target.Name,
+		Components:          []models.Component{component},
+		TotalResponseTimeMs: elapsed.Milliseconds(),
+		Timestamp:           time.Now().UTC(),
+	}, nil
+}
+
+// grpcErrorClass tells what kind of failure a health check RPC error is from
+// its status code, since gRPC errors don't wrap the underlying network error
+func grpcErrorClass(err error) string {
+	switch status.Code(err) {
+	case codes.DeadlineExceeded:
+		return ErrorTimeout
+	case codes.Unavailable:
+		return ErrorConnection
+	case codes.Unauthenticated, codes.PermissionDenied:
+		return ErrorStatus
+	default:
+		return errorClass(err)
+	}
+}
@@ -0,0 +1,164 @@
+package monitoring
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/config"
+	"github.com/openlearnnitj/openlearn-monitoring/internal/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// newGRPCServer starts an in-process gRPC server exposing the standard
+// health service, rejecting calls without the expected token metadata
+func newGRPCServer(t *testing.T, opts ...grpc.ServerOption) (string, *health.Server) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	requireToken := func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if md, _ := metadata.FromIncomingContext(ctx); len(md.Get("x-api-secret")) == 0 || md.Get("x-api-secret")[0] != "secret" {
+			return nil, status.Error(codes.Unauthenticated, "missing token")
+		}
+		return handler(ctx, req)
+	}
+
+	server := grpc.NewServer(append(opts, grpc.UnaryInterceptor(requireToken))...)
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+	return listener.Addr().String(), healthServer
+}
+
+func grpcTarget(address, service string) config.Target {
+	return config.Target{
+		Name:       "payments",
+		Kind:       config.KindGRPC,
+		Address:    address,
+		Service:    service,
+		AuthHeader: "X-API-Secret",
+		AuthValue:  "secret",
+		Timeout:    config.Duration(time.Second),
+	}
+}
+
+func TestCheckGRPC(t *testing.T) {
+	address, healthServer := newGRPCServer(t)
+	healthServer.SetServingStatus("payments.v1.Payments", healthpb.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus("payments.v1.Refunds", healthpb.HealthCheckResponse_NOT_SERVING)
+	healthServer.SetServingStatus("payments.v1.Ledger", healthpb.HealthCheckResponse_UNKNOWN)
+
+	unauthenticated := grpcTarget(address, "")
+	unauthenticated.AuthValue = "wrong"
+
+	tests := []struct {
+		name        string
+		target      config.Target
+		want        string
+		wantMessage string
+		wantClass   string
+	}{
+		{"whole server", grpcTarget(address, ""), models.StatusOperational, "", ""},
+		{"serving service", grpcTarget(address, "payments.v1.Payments"), models.StatusOperational, "", ""},
+		{"not serving service", grpcTarget(address, "payments.v1.Refunds"), models.StatusDown, "serving status is NOT_SERVING", ""},
+		{"unknown status", grpcTarget(address, "payments.v1.Ledger"), models.StatusDegraded, "serving status is UNKNOWN", ""},
+		{"unregistered service", grpcTarget(address, "payments.v1.Missing"), models.StatusDown, "is unknown to the health server", ErrorResponse},
+		{"auth metadata is sent", unauthenticated, models.StatusDown, "Unauthenticated", ErrorStatus},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewService(nil).CheckHealth(context.Background(), tt.target)
+			if err != nil {
+				t.Fatalf("CheckHealth() error = %v", err)
+			}
+
+			component := result.Components[0]
+			if component.Status != tt.want || !strings.Contains(component.Message, tt.wantMessage) {
+				t.Errorf("component = %s %q, want %s %q", component.Status, component.Message, tt.want, tt.wantMessage)
+			}
+			if component.ErrorClass != tt.wantClass {
+				t.Errorf("ErrorClass = %q, want %q", component.ErrorClass, tt.wantClass)
+			}
+		})
+	}
+}
+
+func TestCheckGRPCTLS(t *testing.T) {
+	creds := credentials.NewTLS(&tls.Config{Certificates: []tls.Certificate{selfSigned(t, 90*24*time.Hour)}})
+	address, _ := newGRPCServer(t, grpc.Creds(creds))
+
+	tests := []struct {
+		name   string
+		target func(config.Target) config.Target
+		want   string
+	}{
+		{
+			name: "tls without verification",
+			target: func(t config.Target) config.Target {
+				t.TLS, t.TLSSkipVerify = true, true
+				return t
+			},
+			want: models.StatusOperational,
+		},
+		{
+			name: "untrusted certificate",
+			target: func(t config.Target) config.Target {
+				t.TLS, t.ServerName = true, "localhost"
+				return t
+			},
+			want: models.StatusDown,
+		},
+		{
+			name:   "plaintext against a tls server",
+			target: func(t config.Target) config.Target { return t },
+			want:   models.StatusDown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewService(nil).CheckHealth(context.Background(), tt.target(grpcTarget(address, "")))
+			if err != nil {
+				t.Fatalf("CheckHealth() error = %v", err)
+			}
+			if got := result.Components[0]; got.Status != tt.want {
+				t.Errorf("status = %s (%s), want %s", got.Status, got.Message, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckGRPCUnreachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	address := listener.Addr().String()
+	listener.Close()
+
+	target := grpcTarget(address, "")
+	target.Timeout = config.Duration(200 * time.Millisecond)
+
+	result, err := NewService(nil).CheckHealth(context.Background(), target)
+	if err != nil {
+		t.Fatalf("CheckHealth() error = %v", err)
+	}
+	if got := result.Components[0]; got.Status != models.StatusDown || got.ErrorClass != ErrorConnection {
+		t.Errorf("component = %s (class %q), want %s (class %q)", got.Status, got.ErrorClass, models.StatusDown, ErrorConnection)
+	}
+}
@@ -0,0 +1,141 @@
+package monitoring
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/config"
+	"github.com/openlearnnitj/openlearn-monitoring/internal/models"
+)
+
+// maxBodyBytes caps how much of a response body is read for assertions
+const maxBodyBytes = 1 << 20
+
+// checkHTTP requests an arbitrary URL and grades the response against the
+// target's assertions. Unlike health endpoints, a failing site is a result
+// rather than an error: it is recorded as a single DOWN component named
+// after the target.
+func (s *Service) checkHTTP(ctx context.Context, target config.Target) (*models.MonitoringResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(target.Timeout))
+	defer cancel()
+
+	var body io.Reader
+	if target.Body != "" {
+		body = strings.NewReader(target.Body)
+	}
+	req, err := http.NewRequestWithContext(ctx, target.Method, target.URL, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	setHeaders(req, target)
+
+	client := *s.client
+	if target.Redirects == config.RedirectsNone {
+		client.CheckRedirect = func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+	}
+
+	start := time.Now()
+	components := []models.Component{{Name: target.Name, Status: models.StatusOperational}}
+	component := &components[0]
+	data, state, err := fetchAndAssert(&client, req, responseChecks{
+		expectStatus: target.ExpectStatus,
+		bodyContains: target.BodyContains,
+		bodyMatches:  target.BodyMatches,
+	})
+	elapsed := time.Since(start)
+
+	// The certificate is graded whenever a TLS connection was made, even if
+	// the response itself failed its assertions
+	if target.CheckCertificate && state != nil {
+		gradeCertificate(component, target, state.PeerCertificates)
+	}
+
+	if err != nil {
+		degrade(component, models.StatusDown, err.Error())
+		component.ErrorClass = errorClass(err)
+	} else if len(target.Assertions) > 0 {
+		var doc any
+		if err := json.Unmarshal(data, &doc); err != nil {
+			degrade(component, models.StatusDown, "response body is not valid JSON")
+		} else {
+			applyAssertions(target.Assertions, doc, components)
+		}
+	}
+	if target.MaxLatency > 0 && elapsed > time.Duration(target.MaxLatency) {
+		degrade(component, models.StatusDegraded, fmt.Sprintf("response took %s, above the %s limit", elapsed.Round(time.Millisecond), time.Duration(target.MaxLatency)))
+	}
+	if component.Message != "" {
+		log.Printf("Target %s is %s: %s", target.Name, component.Status, component.Message)
+	}
+	component.ResponseTimeMs = float64(elapsed.Milliseconds())
+
+	return &models.MonitoringResult{
+		Target:              target.Name,
+		Components:          components,
+		TotalResponseTimeMs: elapsed.Milliseconds(),
+		Timestamp:           time.Now().UTC(),
+	}, nil
+}
+
+// responseChecks are the assertions on an HTTP response shared by HTTP
+// probes and the steps of synthetic checks
+type responseChecks struct {
+	expectStatus []int
+	bodyContains string
+	bodyMatches  string
+}
+
+// fetchAndAssert performs the request and checks its status code and body,
+// returning the body for further assertions along with the connection's TLS
+// state, if any
+func fetchAndAssert(client *http.Client, req *http.Request, checks responseChecks) ([]byte, *tls.ConnectionState, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if !expectedStatus(checks.expectStatus, resp.StatusCode) {
+		return nil, resp.TLS, classify(ErrorStatus, fmt.Errorf("unexpected HTTP status: %d", resp.StatusCode))
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBodyBytes))
+	if err != nil {
+		return nil, resp.TLS, classify(ErrorResponse, fmt.Errorf("failed to read response body: %w", err))
+	}
+
+	if checks.bodyContains != "" && !strings.Contains(string(data), checks.bodyContains) {
+		return nil, resp.TLS, classify(ErrorResponse, fmt.Errorf("response body does not contain %q", checks.bodyContains))
+	}
+	if checks.bodyMatches != "" {
+		pattern, err := regexp.Compile(checks.bodyMatches)
+		if err != nil {
+			return nil, resp.TLS, fmt.Errorf("invalid bodyMatches pattern: %w", err)
+		}
+		if !pattern.Match(data) {
+			return nil, resp.TLS, classify(ErrorResponse, fmt.Errorf("response body does not match %q", checks.bodyMatches))
+		}
+	}
+
+	return data, resp.TLS, nil
+}
+
+// expectedStatus reports whether code is one of the expected status codes,
+// or any 2xx code if none are configured
+func expectedStatus(expected []int, code int) bool {
+	if len(expected) == 0 {
+		return code >= 200 && code < 300
+	}
+	return slices.Contains(expected, code)
+}
@@ -0,0 +1,176 @@
+package monitoring
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/config"
+	"github.com/openlearnnitj/openlearn-monitoring/internal/models"
+)
+
+// newSiteServer serves a small website with a redirect, a slow page and an
+// endpoint echoing the request
+func newSiteServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/{$}", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html><title>OpenLearn</title> build 1234</html>"))
+	})
+	mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/", http.StatusFound)
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+	})
+	mux.HandleFunc("/echo", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Method != http.MethodPost || r.Header.Get("X-Token") != "abc" || string(body) != `{"ping":true}` {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func httpTarget(url string) config.Target {
+	return config.Target{
+		Name:      "site",
+		Kind:      config.KindHTTP,
+		URL:       url,
+		Timeout:   config.Duration(time.Second),
+		Method:    http.MethodGet,
+		Redirects: config.RedirectsFollow,
+	}
+}
+
+func TestCheckHTTP(t *testing.T) {
+	server := newSiteServer(t)
+
+	tests := []struct {
+		name   string
+		target func(config.Target) config.Target
+		want   string
+	}{
+		{
+			name:   "2xx is operational by default",
+			target: func(t config.Target) config.Target { return t },
+			want:   models.StatusOperational,
+		},
+		{
+			name: "method, headers and body are sent",
+			target: func(t config.Target) config.Target {
+				t.URL += "/echo"
+				t.Method = http.MethodPost
+				t.Headers = map[string]string{"X-Token": "abc"}
+				t.Body = `{"ping":true}`
+				t.ExpectStatus = []int{201}
+				return t
+			},
+			want: models.StatusOperational,
+		},
+		{
+			name:   "unexpected status is down",
+			target: func(t config.Target) config.Target { t.URL += "/missing"; return t },
+			want:   models.StatusDown,
+		},
+		{
+			name: "expected non-2xx status is operational",
+			target: func(t config.Target) config.Target {
+				t.URL += "/missing"
+				t.ExpectStatus = []int{404}
+				return t
+			},
+			want: models.StatusOperational,
+		},
+		{
+			name:   "body substring",
+			target: func(t config.Target) config.Target { t.BodyContains = "<title>OpenLearn</title>"; return t },
+			want:   models.StatusOperational,
+		},
+		{
+			name:   "missing body substring is down",
+			target: func(t config.Target) config.Target { t.BodyContains = "Maintenance"; return t },
+			want:   models.StatusDown,
+		},
+		{
+			name:   "body regex",
+			target: func(t config.Target) config.Target { t.BodyMatches = `build \d+`; return t },
+			want:   models.StatusOperational,
+		},
+		{
+			name:   "body regex mismatch is down",
+			target: func(t config.Target) config.Target { t.BodyMatches = `^\{`; return t },
+			want:   models.StatusDown,
+		},
+		{
+			name: "slow response is degraded",
+			target: func(t config.Target) config.Target {
+				t.URL += "/slow"
+				t.MaxLatency = config.Duration(10 * time.Millisecond)
+				return t
+			},
+			want: models.StatusDegraded,
+		},
+		{
+			name:   "redirects are followed",
+			target: func(t config.Target) config.Target { t.URL += "/old"; return t },
+			want:   models.StatusOperational,
+		},
+		{
+			name: "redirects can be disabled",
+			target: func(t config.Target) config.Target {
+				t.URL += "/old"
+				t.Redirects = config.RedirectsNone
+				return t
+			},
+			want: models.StatusDown,
+		},
+		{
+			name: "redirect status can be expected",
+			target: func(t config.Target) config.Target {
+				t.URL += "/old"
+				t.Redirects = config.RedirectsNone
+				t.ExpectStatus = []int{302}
+				return t
+			},
+			want: models.StatusOperational,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewService(nil).CheckHealth(context.Background(), tt.target(httpTarget(server.URL)))
+			if err != nil {
+				t.Fatalf("CheckHealth() error = %v", err)
+			}
+			if len(result.Components) != 1 || result.Components[0].Name != "site" {
+				t.Fatalf("components = %+v, want a single site component", result.Components)
+			}
+			if got := result.Components[0].Status; got != tt.want {
+				t.Errorf("status = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckHTTPUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	result, err := NewService(nil).CheckHealth(context.Background(), httpTarget(url))
+	if err != nil {
+		t.Fatalf("CheckHealth() error = %v", err)
+	}
+	if got := result.Components[0].Status; got != models.StatusDown {
+		t.Errorf("status = %s, want %s", got, models.StatusDown)
+	}
+}
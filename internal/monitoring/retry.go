@@ -0,0 +1,109 @@
+package monitoring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/backoff"
+	"github.com/openlearnnitj/openlearn-monitoring/internal/config"
+	"github.com/openlearnnitj/openlearn-monitoring/internal/models"
+)
+
+// checkWithRetries probes a target up to target.Retries extra times,
+// doubling the backoff between attempts. An attempt fails when the probe
+// errors or reports a component DOWN. Retrying stops early once ctx is done.
+// The last attempt is returned; when there was more than one, every outcome
+// is kept in the result's Attempts so masked flakiness stays visible.
+func (s *Service) checkWithRetries(ctx context.Context, target config.Target) (*models.MonitoringResult, error) {
+	var attempts []models.Attempt
+	delay := time.Duration(target.RetryBackoff)
+
+	for {
+		start := time.Now()
+		result, err := s.probe(ctx, target)
+		attempt := describeAttempt(result, err, time.Since(start))
+		attempts = append(attempts, attempt)
+
+		if attempt.Status != models.StatusDown || len(attempts) > target.Retries {
+			return withAttempts(result, err, attempts)
+		}
+
+		log.Printf("Attempt %d of %d for %s failed: %s; retrying in %s",
+			len(attempts), target.Retries+1, target.Label(), attempt.Message, delay)
+		if !backoff.Wait(ctx, delay) {
+			log.Printf("Giving up on %s: no time left to retry", target.Label())
+			return withAttempts(result, err, attempts)
+		}
+		delay *= 2
+	}
+}
+
+// withAttempts returns the last attempt's outcome, keeping every attempt
+// when there was more than one
+func withAttempts(result *models.MonitoringResult, err error, attempts []models.Attempt) (*models.MonitoringResult, error) {
+	if len(attempts) == 1 {
+		return result, err
+	}
+	if err != nil {
+		return nil, &AttemptsError{Attempts: attempts, Err: err}
+	}
+	result.Attempts = attempts
+	return result, nil
+}
+
+// AttemptsError is returned when every attempt of a retried check failed.
+// It keeps the outcome of each attempt, so they can be stored with the
+// failure.
+type AttemptsError struct {
+	Attempts []models.Attempt
+	Err      error // the last attempt's error
+}
+
+func (e *AttemptsError) Error() string {
+	return fmt.Sprintf("%d attempts failed, last: %v", len(e.Attempts), e.Err)
+}
+
+func (e *AttemptsError) Unwrap() error { return e.Err }
+
+// Attempts returns the outcome of each attempt of a check that failed
+// after retries, or nil if err isn't from a retried check
+func Attempts(err error) []models.Attempt {
+	var attemptsErr *AttemptsError
+	if errors.As(err, &attemptsErr) {
+		return attemptsErr.Attempts
+	}
+	return nil
+}
+
+// describeAttempt summarises one probe: the worst component status and
+// what was wrong, or DOWN with the error
+func describeAttempt(result *models.MonitoringResult, err error, elapsed time.Duration) models.Attempt {
+	attempt := models.Attempt{
+		Status:     models.StatusOperational,
+		DurationMs: float64(elapsed.Microseconds()) / 1000,
+	}
+	if err != nil {
+		attempt.Status = models.StatusDown
+		attempt.Message = err.Error()
+		return attempt
+	}
+
+	var problems []string
+	for _, component := range result.Components {
+		attempt.Status = models.WorseStatus(attempt.Status, component.Status)
+		if component.Status == models.StatusOperational {
+			continue
+		}
+		problem := component.Name + " " + component.Status
+		if component.Message != "" {
+			problem += ": " + component.Message
+		}
+		problems = append(problems, problem)
+	}
+	attempt.Message = strings.Join(problems, "; ")
+	return attempt
+}
@@ -0,0 +1,133 @@
+package monitoring
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/config"
+	"github.com/openlearnnitj/openlearn-monitoring/internal/models"
+)
+
+// newFlakyServer fails the first failures requests with a 503, then serves
+// healthBody
+func newFlakyServer(t *testing.T, failures int32) *httptest.Server {
+	t.Helper()
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(healthBody))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCheckHealthRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		failures     int32
+		retries      int
+		wantErr      string
+		wantAttempts []string
+	}{
+		{"healthy first time", 0, 2, "", nil},
+		{"retry masks a failure", 1, 2, "", []string{"DOWN", "DEGRADED"}},
+		{"retries exhausted", 5, 2, "3 attempts failed, last: unexpected HTTP status: 503", []string{"DOWN", "DOWN", "DOWN"}},
+		{"no retries", 1, 0, "unexpected HTTP status: 503", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			probe := target("api", newFlakyServer(t, tt.failures).URL, config.FormatComponents)
+			probe.Retries = tt.retries
+			probe.RetryBackoff = config.Duration(time.Millisecond)
+
+			result, err := NewService(nil).CheckHealth(context.Background(), probe)
+			var attempts []models.Attempt
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("CheckHealth() error = %v, want %q", err, tt.wantErr)
+				}
+				attempts = Attempts(err)
+				if class := errorClass(err); class != ErrorStatus {
+					t.Errorf("errorClass() = %q, want %q", class, ErrorStatus)
+				}
+			} else if err != nil {
+				t.Fatalf("CheckHealth() error = %v", err)
+			} else {
+				attempts = result.Attempts
+			}
+
+			var got []string
+			for _, attempt := range attempts {
+				got = append(got, attempt.Status)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.wantAttempts, ",") {
+				t.Errorf("Attempts = %v, want %v", got, tt.wantAttempts)
+			}
+			if len(attempts) > 0 && !strings.Contains(attempts[0].Message, "503") {
+				t.Errorf("first attempt message = %q, want the error", attempts[0].Message)
+			}
+		})
+	}
+}
+
+func TestCheckHealthRetriesDownComponents(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+
+	site := httpTarget(server.URL)
+	site.Retries = 1
+	site.RetryBackoff = config.Duration(time.Millisecond)
+
+	result, err := NewService(nil).CheckHealth(context.Background(), site)
+	if err != nil {
+		t.Fatalf("CheckHealth() error = %v", err)
+	}
+	if requests.Load() != 2 || len(result.Attempts) != 2 {
+		t.Fatalf("got %d requests and %d attempts, want 2", requests.Load(), len(result.Attempts))
+	}
+	if result.Components[0].Status != "DOWN" || result.Attempts[1].Message != "site DOWN: "+result.Components[0].Message {
+		t.Errorf("component = %+v, attempts = %+v", result.Components[0], result.Attempts)
+	}
+}
+
+func TestCheckHealthContext(t *testing.T) {
+	hanging := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	t.Cleanup(hanging.Close)
+
+	probe := target("api", hanging.URL, config.FormatComponents)
+	probe.Timeout = config.Duration(30 * time.Second)
+	probe.Retries = 3
+	probe.RetryBackoff = config.Duration(time.Second)
+
+	// The caller's deadline wins over the target's timeout, and there's no
+	// time left to retry once it has passed
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := NewService(nil).CheckHealth(ctx, probe)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("CheckHealth() took %s, want it to stop at the deadline", elapsed)
+	}
+	if err == nil || errorClass(err) != ErrorTimeout {
+		t.Errorf("CheckHealth() error = %v, want a timeout", err)
+	}
+	if err != nil && strings.Contains(err.Error(), "attempts failed") {
+		t.Errorf("CheckHealth() error = %v, want no retries", err)
+	}
+}
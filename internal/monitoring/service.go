@@ -1,44 +1,78 @@
 package monitoring
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"time"
 
+	"github.com/openlearnnitj/openlearn-monitoring/internal/config"
 	"github.com/openlearnnitj/openlearn-monitoring/internal/models"
 )
 
 // Service handles monitoring operations
 type Service struct {
-	apiURL    string
-	apiSecret string
-	client    *http.Client
+	targets []config.Target
+	client  *http.Client
 }
 
-// NewService creates a new monitoring service
-func NewService(apiURL, apiSecret string) *Service {
+// NewService creates a new monitoring service for the given targets
+func NewService(targets []config.Target) *Service {
 	return &Service{
-		apiURL:    apiURL,
-		apiSecret: apiSecret,
-		client: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		targets: targets,
+		// Timeouts are applied per target on each request
+		client: &http.Client{},
 	}
 }
 
-// CheckHealth performs a health check against the monitoring endpoint
-func (s *Service) CheckHealth() (*models.MonitoringResult, error) {
+// Targets returns the targets checked by the service
+func (s *Service) Targets() []config.Target {
+	return s.targets
+}
+
+// CheckHealth checks a target, retrying failed attempts as configured. Each
+// attempt is bounded by the target's timeout and by ctx, whose cancellation
+// also stops further retries.
+func (s *Service) CheckHealth(ctx context.Context, target config.Target) (*models.MonitoringResult, error) {
+	return s.checkWithRetries(ctx, target)
+}
+
+// probe checks a target once with the probe matching its kind
+func (s *Service) probe(ctx context.Context, target config.Target) (*models.MonitoringResult, error) {
+	switch target.Kind {
+	case config.KindHTTP:
+		return s.checkHTTP(ctx, target)
+	case config.KindTCP:
+		return s.checkTCP(ctx, target)
+	case config.KindTLS:
+		return s.checkTLS(ctx, target)
+	case config.KindDNS:
+		return s.checkDNS(ctx, target)
+	case config.KindGRPC:
+		return s.checkGRPC(ctx, target)
+	case config.KindSynthetic:
+		return s.checkSynthetic(ctx, target)
+	default:
+		return s.checkHealthEndpoint(ctx, target)
+	}
+}
+
+// checkHealthEndpoint reads the components reported by an OpenLearn health endpoint
+func (s *Service) checkHealthEndpoint(ctx context.Context, target config.Target) (*models.MonitoringResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(target.Timeout))
+	defer cancel()
+
 	// Create request
-	req, err := http.NewRequest("GET", s.apiURL, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", target.URL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Add required headers
-	req.Header.Set("X-API-Secret", s.apiSecret)
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("User-Agent", "OpenLearn-Monitoring/1.0")
+	setHeaders(req, target)
 
 	// Measure response time
 	start := time.Now()
@@ -52,18 +86,73 @@ func (s *Service) CheckHealth() (*models.MonitoringResult, error) {
 
 	// Check status code
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected HTTP status: %d", resp.StatusCode)
+		return nil, classify(ErrorStatus, fmt.Errorf("unexpected HTTP status: %d", resp.StatusCode))
 	}
 
 	// Parse response
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBodyBytes))
+	if err != nil {
+		return nil, classify(ErrorResponse, fmt.Errorf("failed to read response: %w", err))
+	}
 	var healthResp models.HealthStatusResponse
-	if err := json.NewDecoder(resp.Body).Decode(&healthResp); err != nil {
-		return nil, fmt.Errorf("failed to decode JSON response: %w", err)
+	if err := json.Unmarshal(data, &healthResp); err != nil {
+		return nil, classify(ErrorResponse, fmt.Errorf("failed to decode JSON response: %w", err))
+	}
+
+	var components []models.Component
+	switch target.Format {
+	case config.FormatOverall:
+		components = []models.Component{{
+			Name:           target.Name,
+			Status:         healthResp.OverallStatus,
+			ResponseTimeMs: float64(totalResponseTime),
+		}}
+	default:
+		components = healthResp.Components
+	}
+
+	if len(target.Assertions) > 0 {
+		var doc any
+		json.Unmarshal(data, &doc)
+		applyAssertions(target.Assertions, doc, components)
+	}
+
+	if target.Format != config.FormatOverall {
+		for i := range components {
+			components[i].Name = QualifiedName(target.Name, components[i].Name)
+		}
+		// The endpoint answered, so the TargetComponent FailedResult records
+		// when it doesn't is operational again
+		components = append(components, models.Component{
+			Name:           QualifiedName(target.Name, TargetComponent),
+			Status:         models.StatusOperational,
+			ResponseTimeMs: float64(totalResponseTime),
+		})
 	}
 
 	return &models.MonitoringResult{
-		Components:          healthResp.Components,
+		Target:              target.Name,
+		Components:          components,
 		TotalResponseTimeMs: totalResponseTime,
-		Timestamp:          time.Now().UTC(),
+		Timestamp:           time.Now().UTC(),
 	}, nil
 }
+
+// QualifiedName places a component name under its target's namespace
+func QualifiedName(target, component string) string {
+	if target == "" {
+		return component
+	}
+	return target + "/" + component
+}
+
+// setHeaders adds the user agent and the target's auth and custom headers
+func setHeaders(req *http.Request, target config.Target) {
+	req.Header.Set("User-Agent", "OpenLearn-Monitoring/1.0")
+	if target.AuthValue != "" {
+		req.Header.Set(target.AuthHeader, target.AuthValue)
+	}
+	for key, value := range target.Headers {
+		req.Header.Set(key, value)
+	}
+}
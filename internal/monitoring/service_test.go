@@ -0,0 +1,131 @@
+package monitoring
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/config"
+)
+
+const healthBody = `{
+	"timestamp": "2025-06-15T12:00:00Z",
+	"overallStatus": "DEGRADED",
+	"components": [
+		{"name": "Database", "status": "OPERATIONAL", "responseTimeMs": 12.5},
+		{"name": "Redis", "status": "DEGRADED", "responseTimeMs": 80}
+	]
+}`
+
+// newHealthServer serves body with the given status, requiring the auth header
+func newHealthServer(t *testing.T, status int, body string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Secret") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func target(name, url, format string) config.Target {
+	return config.Target{
+		Name:       name,
+		URL:        url,
+		AuthHeader: "X-API-Secret",
+		AuthValue:  "secret",
+		Timeout:    config.Duration(time.Second),
+		Format:     format,
+	}
+}
+
+func TestCheckHealthFormats(t *testing.T) {
+	server := newHealthServer(t, http.StatusOK, healthBody)
+
+	tests := []struct {
+		name   string
+		target config.Target
+		want   map[string]string
+	}{
+		{
+			name:   "unnamed target keeps component names",
+			target: target("", server.URL, config.FormatComponents),
+			want:   map[string]string{"Database": "OPERATIONAL", "Redis": "DEGRADED", "target": "OPERATIONAL"},
+		},
+		{
+			name:   "named target namespaces components",
+			target: target("api", server.URL, config.FormatComponents),
+			want:   map[string]string{"api/Database": "OPERATIONAL", "api/Redis": "DEGRADED", "api/target": "OPERATIONAL"},
+		},
+		{
+			name:   "overall format records the target itself",
+			target: target("api", server.URL, config.FormatOverall),
+			want:   map[string]string{"api": "DEGRADED"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewService(nil).CheckHealth(context.Background(), tt.target)
+			if err != nil {
+				t.Fatalf("CheckHealth() error = %v", err)
+			}
+			if result.Target != tt.target.Name {
+				t.Errorf("Target = %q, want %q", result.Target, tt.target.Name)
+			}
+
+			got := make(map[string]string)
+			for _, component := range result.Components {
+				got[component.Name] = component.Status
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("components = %v, want %v", got, tt.want)
+			}
+			for name, status := range tt.want {
+				if got[name] != status {
+					t.Errorf("%s = %q, want %q", name, got[name], status)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckHealthErrors(t *testing.T) {
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	t.Cleanup(slow.Close)
+
+	slowTarget := target("slow", slow.URL, config.FormatComponents)
+	slowTarget.Timeout = config.Duration(50 * time.Millisecond)
+
+	wrongSecret := target("api", newHealthServer(t, http.StatusOK, healthBody).URL, config.FormatComponents)
+	wrongSecret.AuthValue = "wrong"
+
+	tests := []struct {
+		name    string
+		target  config.Target
+		wantErr string
+	}{
+		{"non-200 status", target("api", newHealthServer(t, http.StatusServiceUnavailable, "").URL, config.FormatComponents), "unexpected HTTP status: 503"},
+		{"auth header is sent", wrongSecret, "unexpected HTTP status: 401"},
+		{"invalid json", target("api", newHealthServer(t, http.StatusOK, "<html>").URL, config.FormatComponents), "failed to decode JSON"},
+		{"per-target timeout", slowTarget, "HTTP request failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewService(nil).CheckHealth(context.Background(), tt.target)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("CheckHealth() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package monitoring
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/cookiejar"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/config"
+	"github.com/openlearnnitj/openlearn-monitoring/internal/jsonpath"
+	"github.com/openlearnnitj/openlearn-monitoring/internal/models"
+)
+
+// checkSynthetic runs the target's steps in order as one transaction, such
+// as logging in and loading a course. It's recorded as a single component
+// with the outcome and latency of every step that ran. The target's timeout
+// covers the whole transaction.
+func (s *Service) checkSynthetic(ctx context.Context, target config.Target) (*models.MonitoringResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(target.Timeout))
+	defer cancel()
+
+	// Cookies set by one step, such as a session, are sent by the next
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cookie jar: %w", err)
+	}
+	client := *s.client
+	client.Jar = jar
+
+	component := models.Component{Name: target.Name, Status: models.StatusOperational}
+	variables := make(map[string]string)
+
+	start := time.Now()
+	for _, step := range target.Steps {
+		result := runStep(ctx, &client, target, step, variables)
+		component.Steps = append(component.Steps, result)
+
+		if result.Status != models.StatusOperational {
+			degrade(&component, result.Status, fmt.Sprintf("%s: %s", step.Name, result.Message))
+		}
+		// Later steps depend on this one having worked
+		if result.Status == models.StatusDown {
+			component.ErrorClass = result.ErrorClass
+			break
+		}
+	}
+	elapsed := time.Since(start)
+
+	if target.MaxLatency > 0 && elapsed > time.Duration(target.MaxLatency) {
+		degrade(&component, models.StatusDegraded, fmt.Sprintf("transaction took %s, above the %s limit", elapsed.Round(time.Millisecond), time.Duration(target.MaxLatency)))
+	}
+	if component.Message != "" {
+		log.Printf("Target %s is %s: %s", target.Name, component.Status, component.Message)
+	}
+	component.ResponseTimeMs = float64(elapsed.Milliseconds())
+
+	return &models.MonitoringResult{
+		Target:              target.Name,
+		Components:          []models.Component{component},
+		TotalResponseTimeMs: elapsed.Milliseconds(),
+		Timestamp:           time.Now().UTC(),
+	}, nil
+}
+
+// runStep performs one step and checks its response, adding the variables
+// it extracts for the steps after it
+func runStep(ctx context.Context, client *http.Client, target config.Target, step config.Step, variables map[string]string) models.StepResult {
+	// A one-element slice lets the step share applyAssertions with probes
+	components := []models.Component{{Name: step.Name, Status: models.StatusOperational}}
+	outcome := &components[0]
+
+	var body io.Reader
+	if step.Body != "" {
+		body = strings.NewReader(config.Expand(step.Body, variables))
+	}
+
+	start := time.Now()
+	req, err := http.NewRequestWithContext(ctx, step.Method, config.Expand(step.URL, variables), body)
+	if err != nil {
+		degrade(outcome, models.StatusDown, fmt.Sprintf("failed to create request: %v", err))
+		outcome.ErrorClass = errorClass(err)
+	} else {
+		setHeaders(req, target)
+		for key, value := range step.Headers {
+			req.Header.Set(key, config.Expand(value, variables))
+		}
+
+		data, _, err := fetchAndAssert(client, req, responseChecks{
+			expectStatus: step.ExpectStatus,
+			bodyContains: step.BodyContains,
+			bodyMatches:  step.BodyMatches,
+		})
+		if err != nil {
+			degrade(outcome, models.StatusDown, err.Error())
+			outcome.ErrorClass = errorClass(err)
+		} else if len(step.Assertions) > 0 || len(step.Extract) > 0 {
+			checkStepBody(step, data, components, variables)
+		}
+	}
+	elapsed := time.Since(start)
+
+	if step.MaxLatency > 0 && elapsed > time.Duration(step.MaxLatency) {
+		degrade(outcome, models.StatusDegraded, fmt.Sprintf("took %s, above the %s limit", elapsed.Round(time.Millisecond), time.Duration(step.MaxLatency)))
+	}
+
+	return models.StepResult{
+		Name:       step.Name,
+		Status:     outcome.Status,
+		DurationMs: float64(elapsed.Milliseconds()),
+		Message:    outcome.Message,
+		ErrorClass: outcome.ErrorClass,
+	}
+}
+
+// checkStepBody applies a step's assertions to its JSON response and
+// extracts its variables
+func checkStepBody(step config.Step, data []byte, components []models.Component, variables map[string]string) {
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		degrade(&components[0], models.StatusDown, "response body is not valid JSON")
+		return
+	}
+
+	applyAssertions(step.Assertions, doc, components)
+
+	names := make([]string, 0, len(step.Extract))
+	for name := range step.Extract {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		path, err := jsonpath.ParsePath(step.Extract[name])
+		if err != nil {
+			degrade(&components[0], models.StatusDown, fmt.Sprintf("invalid path for %s: %v", name, err))
+			continue
+		}
+		value, ok := path.Lookup(doc)
+		if !ok {
+			degrade(&components[0], models.StatusDown, fmt.Sprintf("%s is missing, can't extract %s", path, name))
+			continue
+		}
+		variables[name] = variableValue(value)
+	}
+}
+
+// variableValue renders an extracted JSON value for use in later requests:
+// strings as they are, anything else as JSON
+func variableValue(value any) string {
+	if text, ok := value.(string); ok {
+		return text
+	}
+	data, _ := json.Marshal(value)
+	return string(data)
+}
@@ -0,0 +1,152 @@
+package monitoring
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/config"
+	"github.com/openlearnnitj/openlearn-monitoring/internal/models"
+)
+
+// newLearningServer serves a login that returns a token and sets a session
+// cookie, and a course page requiring both
+func newLearningServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /login", func(w http.ResponseWriter, r *http.Request) {
+		var creds struct{ Email, Password string }
+		json.NewDecoder(r.Body).Decode(&creds)
+		if creds.Password != "hunter2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "s1"})
+		w.Write([]byte(`{"token": "abc", "user": {"id": 42}}`))
+	})
+	mux.HandleFunc("GET /courses/{id}", func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie("session")
+		if err != nil || cookie.Value != "s1" || r.Header.Get("Authorization") != "Bearer abc" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.PathValue("id") != "42" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"title": "Go 101", "lessons": 3}`))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func syntheticTarget(url, password string, courseAssertion config.Assertion) config.Target {
+	return config.Target{
+		Name:    "learner-journey",
+		Kind:    config.KindSynthetic,
+		Timeout: config.Duration(time.Second),
+		Steps: []config.Step{
+			{
+				Name:    "log in",
+				Method:  http.MethodPost,
+				URL:     url + "/login",
+				Headers: map[string]string{"Content-Type": "application/json"},
+				Body:    `{"email": "probe@openlearn.org.in", "password": "` + password + `"}`,
+				Extract: map[string]string{"token": "$.token", "userID": "$.user.id"},
+			},
+			{
+				Name:       "load course",
+				Method:     http.MethodGet,
+				URL:        url + "/courses/${userID}",
+				Headers:    map[string]string{"Authorization": "Bearer ${token}"},
+				Assertions: []config.Assertion{courseAssertion},
+			},
+		},
+	}
+}
+
+func TestCheckSynthetic(t *testing.T) {
+	server := newLearningServer(t)
+	passing := config.Assertion{Expr: `$.title == "Go 101"`, Severity: models.StatusDown}
+
+	tests := []struct {
+		name        string
+		target      config.Target
+		want        string
+		wantMessage string
+		wantClass   string
+		wantSteps   []string
+	}{
+		{
+			name:      "every step passes",
+			target:    syntheticTarget(server.URL, "hunter2", passing),
+			want:      models.StatusOperational,
+			wantSteps: []string{models.StatusOperational, models.StatusOperational},
+		},
+		{
+			name:        "failed step stops the transaction",
+			target:      syntheticTarget(server.URL, "wrong", passing),
+			want:        models.StatusDown,
+			wantMessage: "log in: unexpected HTTP status: 401",
+			wantClass:   ErrorStatus,
+			wantSteps:   []string{models.StatusDown},
+		},
+		{
+			name:        "step assertion degrades the transaction",
+			target:      syntheticTarget(server.URL, "hunter2", config.Assertion{Expr: "$.lessons >= 10", Severity: models.StatusDegraded}),
+			want:        models.StatusDegraded,
+			wantMessage: "load course: $.lessons is 3, want >= 10",
+			wantSteps:   []string{models.StatusOperational, models.StatusDegraded},
+		},
+		{
+			name: "missing extracted value",
+			target: func() config.Target {
+				target := syntheticTarget(server.URL, "hunter2", passing)
+				target.Steps[0].Extract["refresh"] = "$.refreshToken"
+				return target
+			}(),
+			want:        models.StatusDown,
+			wantMessage: "log in: $.refreshToken is missing, can't extract refresh",
+			wantSteps:   []string{models.StatusDown},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewService(nil).CheckHealth(context.Background(), tt.target)
+			if err != nil {
+				t.Fatalf("CheckHealth() error = %v", err)
+			}
+
+			component := result.Components[0]
+			if component.Name != "learner-journey" || component.Status != tt.want || !strings.Contains(component.Message, tt.wantMessage) {
+				t.Errorf("component = %s %s %q, want %s %q", component.Name, component.Status, component.Message, tt.want, tt.wantMessage)
+			}
+			if component.ErrorClass != tt.wantClass {
+				t.Errorf("ErrorClass = %q, want %q", component.ErrorClass, tt.wantClass)
+			}
+			if len(component.Steps) != len(tt.wantSteps) {
+				t.Fatalf("got %d steps, want %d: %+v", len(component.Steps), len(tt.wantSteps), component.Steps)
+			}
+			for i, want := range tt.wantSteps {
+				if got := component.Steps[i].Status; got != want {
+					t.Errorf("step %d status = %s, want %s", i+1, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestVariableValue(t *testing.T) {
+	for value, want := range map[any]string{"abc": "abc", 42.0: "42", true: "true", nil: "null"} {
+		if got := variableValue(value); got != want {
+			t.Errorf("variableValue(%v) = %q, want %q", value, got, want)
+		}
+	}
+}
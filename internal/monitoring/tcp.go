@@ -0,0 +1,89 @@
+package monitoring
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/config"
+	"github.com/openlearnnitj/openlearn-monitoring/internal/models"
+)
+
+// maxBannerBytes caps how much a TCP probe reads while waiting for its banner
+const maxBannerBytes = 4096
+
+// checkTCP dials the target's address and, if configured, sends a payload
+// and waits for the expected banner. The connect latency is recorded as the
+// component's response time.
+func (s *Service) checkTCP(ctx context.Context, target config.Target) (*models.MonitoringResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(target.Timeout))
+	defer cancel()
+	deadline, _ := ctx.Deadline()
+
+	component := models.Component{Name: target.Name, Status: models.StatusOperational}
+
+	var dialer net.Dialer
+	start := time.Now()
+	conn, err := dialer.DialContext(ctx, "tcp", target.Address)
+	connectTime := time.Since(start)
+
+	if err != nil {
+		degrade(&component, models.StatusDown, fmt.Sprintf("connect failed: %v", err))
+		component.ErrorClass = errorClass(err)
+	} else {
+		defer conn.Close()
+		if err := exchange(conn, target, deadline); err != nil {
+			degrade(&component, models.StatusDown, err.Error())
+			component.ErrorClass = errorClass(err)
+		}
+	}
+	if err == nil && target.MaxLatency > 0 && connectTime > time.Duration(target.MaxLatency) {
+		degrade(&component, models.StatusDegraded, fmt.Sprintf("connect took %s, above the %s limit", connectTime.Round(time.Millisecond), time.Duration(target.MaxLatency)))
+	}
+	if component.Message != "" {
+		log.Printf("Target %s is %s: %s", target.Name, component.Status, component.Message)
+	}
+	component.ResponseTimeMs = float64(connectTime.Milliseconds())
+
+	return &models.MonitoringResult{
+		Target:              target.Name,
+		Components:          []models.Component{component},
+		TotalResponseTimeMs: time.Since(start).Milliseconds(),
+		Timestamp:           time.Now().UTC(),
+	}, nil
+}
+
+// exchange sends the target's payload and reads until the expected banner
+// arrives, the connection closes or the deadline passes
+func exchange(conn net.Conn, target config.Target, deadline time.Time) error {
+	if target.Send == "" && target.ExpectBanner == "" {
+		return nil
+	}
+	conn.SetDeadline(deadline)
+
+	if target.Send != "" {
+		if _, err := conn.Write([]byte(target.Send)); err != nil {
+			return fmt.Errorf("failed to send payload: %w", err)
+		}
+	}
+	if target.ExpectBanner == "" {
+		return nil
+	}
+
+	var received []byte
+	chunk := make([]byte, 512)
+	for len(received) < maxBannerBytes {
+		n, err := conn.Read(chunk)
+		received = append(received, chunk[:n]...)
+		if strings.Contains(string(received), target.ExpectBanner) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("banner %q not received: %w", target.ExpectBanner, err)
+		}
+	}
+	return classify(ErrorResponse, fmt.Errorf("banner %q not found in the first %d bytes", target.ExpectBanner, maxBannerBytes))
+}
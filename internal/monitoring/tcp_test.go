@@ -0,0 +1,145 @@
+package monitoring
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/config"
+	"github.com/openlearnnitj/openlearn-monitoring/internal/models"
+)
+
+// newTCPServer accepts connections and hands each one to serve
+func newTCPServer(t *testing.T, serve func(net.Conn)) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				serve(conn)
+			}()
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func tcpTarget(address string) config.Target {
+	return config.Target{
+		Name:    "redis",
+		Kind:    config.KindTCP,
+		Address: address,
+		Timeout: config.Duration(200 * time.Millisecond),
+	}
+}
+
+func TestCheckTCP(t *testing.T) {
+	// Greets like an SMTP relay and answers PING like Redis
+	address := newTCPServer(t, func(conn net.Conn) {
+		conn.Write([]byte("220 relay.example.com ESMTP\r\n"))
+		line, err := bufio.NewReader(conn).ReadString('\n')
+		if err == nil && strings.TrimSpace(line) == "PING" {
+			conn.Write([]byte("+PONG\r\n"))
+		}
+	})
+	silent := newTCPServer(t, func(conn net.Conn) {
+		time.Sleep(time.Second)
+	})
+
+	tests := []struct {
+		name      string
+		target    config.Target
+		want      string
+		wantClass string
+	}{
+		{
+			name:   "connect only",
+			target: tcpTarget(address),
+			want:   models.StatusOperational,
+		},
+		{
+			name: "banner on connect",
+			target: func() config.Target {
+				target := tcpTarget(address)
+				target.ExpectBanner = "220 "
+				return target
+			}(),
+			want: models.StatusOperational,
+		},
+		{
+			name: "payload and reply",
+			target: func() config.Target {
+				target := tcpTarget(address)
+				target.Send = "PING\r\n"
+				target.ExpectBanner = "+PONG"
+				return target
+			}(),
+			want: models.StatusOperational,
+		},
+		{
+			name: "wrong banner",
+			target: func() config.Target {
+				target := tcpTarget(address)
+				target.ExpectBanner = "SSH-2.0"
+				return target
+			}(),
+			want:      models.StatusDown,
+			wantClass: ErrorTimeout,
+		},
+		{
+			name: "no banner before timeout",
+			target: func() config.Target {
+				target := tcpTarget(silent)
+				target.ExpectBanner = "220 "
+				return target
+			}(),
+			want:      models.StatusDown,
+			wantClass: ErrorTimeout,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewService(nil).CheckHealth(context.Background(), tt.target)
+			if err != nil {
+				t.Fatalf("CheckHealth() error = %v", err)
+			}
+			if len(result.Components) != 1 || result.Components[0].Name != "redis" {
+				t.Fatalf("components = %+v, want a single redis component", result.Components)
+			}
+			if got := result.Components[0]; got.Status != tt.want || got.ErrorClass != tt.wantClass {
+				t.Errorf("status = %s (%s, class %q), want %s (class %q)", got.Status, got.Message, got.ErrorClass, tt.want, tt.wantClass)
+			}
+		})
+	}
+}
+
+func TestCheckTCPRefused(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	address := listener.Addr().String()
+	listener.Close()
+
+	result, err := NewService(nil).CheckHealth(context.Background(), tcpTarget(address))
+	if err != nil {
+		t.Fatalf("CheckHealth() error = %v", err)
+	}
+	got := result.Components[0]
+	if got.Status != models.StatusDown || !strings.Contains(got.Message, "connect failed") || got.ErrorClass != ErrorConnection {
+		t.Errorf("component = %s %q (class %q), want DOWN with a connect failure", got.Status, got.Message, got.ErrorClass)
+	}
+}
@@ -0,0 +1,174 @@
+package monitoring
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/config"
+	"github.com/openlearnnitj/openlearn-monitoring/internal/models"
+)
+
+// selfSigned creates a certificate for localhost that expires after validFor
+func selfSigned(t *testing.T, validFor time.Duration) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost", Organization: []string{"OpenLearn Test CA"}},
+		DNSNames:     []string{"localhost", "status.localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-365 * 24 * time.Hour),
+		NotAfter:     time.Now().Add(validFor),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+// newTLSServer serves cert on a local port, closing connections after the
+// handshake
+func newTLSServer(t *testing.T, cert tls.Certificate) string {
+	t.Helper()
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: []tls.Certificate{cert}})
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.(*tls.Conn).Handshake()
+			conn.Close()
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func tlsTarget(address string) config.Target {
+	return config.Target{
+		Name:             "cert",
+		Kind:             config.KindTLS,
+		Address:          address,
+		ServerName:       "localhost",
+		Timeout:          config.Duration(time.Second),
+		CertWarningDays:  14,
+		CertCriticalDays: 7,
+	}
+}
+
+func TestCheckTLS(t *testing.T) {
+	tests := []struct {
+		name        string
+		validFor    time.Duration
+		want        string
+		wantMessage string
+	}{
+		{"far from expiry", 90 * 24 * time.Hour, models.StatusOperational, ""},
+		{"below warning threshold", 10*24*time.Hour + time.Hour, models.StatusDegraded, "certificate expires in 10 days"},
+		{"below critical threshold", 3*24*time.Hour + time.Hour, models.StatusDown, "certificate expires in 3 days"},
+		{"expired", -24 * time.Hour, models.StatusDown, "certificate expired on"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			address := newTLSServer(t, selfSigned(t, tt.validFor))
+
+			result, err := NewService(nil).CheckHealth(context.Background(), tlsTarget(address))
+			if err != nil {
+				t.Fatalf("CheckHealth() error = %v", err)
+			}
+
+			component := result.Components[0]
+			if component.Status != tt.want || !strings.Contains(component.Message, tt.wantMessage) {
+				t.Errorf("component = %s %q, want %s %q", component.Status, component.Message, tt.want, tt.wantMessage)
+			}
+			if component.Certificate == nil {
+				t.Fatal("certificate was not recorded")
+			}
+			if want := []string{"localhost", "status.localhost", "127.0.0.1"}; !reflect.DeepEqual(component.Certificate.SANs, want) {
+				t.Errorf("SANs = %v, want %v", component.Certificate.SANs, want)
+			}
+			if !strings.Contains(component.Certificate.Issuer, "OpenLearn Test CA") {
+				t.Errorf("Issuer = %q", component.Certificate.Issuer)
+			}
+		})
+	}
+}
+
+func TestCheckTLSHandshakeFailure(t *testing.T) {
+	// A plain TCP server can't complete a handshake
+	address := newTCPServer(t, func(conn net.Conn) {})
+
+	result, err := NewService(nil).CheckHealth(context.Background(), tlsTarget(address))
+	if err != nil {
+		t.Fatalf("CheckHealth() error = %v", err)
+	}
+	if component := result.Components[0]; component.Status != models.StatusDown || component.Certificate != nil || component.ErrorClass != ErrorTLS {
+		t.Errorf("component = %+v, want DOWN with a TLS failure and without a certificate", component)
+	}
+}
+
+func TestCheckHTTPCertificate(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{selfSigned(t, 5*24*time.Hour)}}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	target := httpTarget(server.URL)
+	target.CheckCertificate = true
+	target.CertWarningDays = 30
+	target.CertCriticalDays = 2
+
+	service := NewService(nil)
+	service.client = server.Client()
+
+	result, err := service.CheckHealth(context.Background(), target)
+	if err != nil {
+		t.Fatalf("CheckHealth() error = %v", err)
+	}
+	component := result.Components[0]
+	if component.Status != models.StatusDegraded || component.Certificate == nil {
+		t.Errorf("component = %+v, want DEGRADED with a certificate", component)
+	}
+}
+
+func TestDescribeChain(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	leaf := &x509.Certificate{NotAfter: now.Add(300 * 24 * time.Hour)}
+	intermediate := &x509.Certificate{NotAfter: now.Add(20*24*time.Hour + time.Minute)}
+
+	got := describeChain([]*x509.Certificate{leaf, intermediate}, now)
+	if !got.ExpiresAt.Equal(intermediate.NotAfter) || got.DaysRemaining != 20 {
+		t.Errorf("describeChain() = %+v, want the intermediate's expiry in 20 days", got)
+	}
+
+	expired := describeChain([]*x509.Certificate{{NotAfter: now.Add(-time.Hour)}}, now)
+	if expired.DaysRemaining != -1 {
+		t.Errorf("DaysRemaining = %d for an expired certificate, want -1", expired.DaysRemaining)
+	}
+}
@@ -0,0 +1,110 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/config"
+	"github.com/openlearnnitj/openlearn-monitoring/internal/leader"
+)
+
+// maxJitter is the largest delay added to a check, as a fraction of its
+// interval, so targets sharing an interval don't all fire at once
+const maxJitter = 0.1
+
+// CheckFunc checks a single target
+type CheckFunc func(ctx context.Context, target config.Target)
+
+// Scheduler runs the check of every target on the target's own interval
+type Scheduler struct {
+	targets []config.Target
+	check   CheckFunc
+	slots   chan struct{}
+	jitter  func(interval time.Duration) time.Duration
+}
+
+// NewScheduler creates a scheduler running at most concurrency checks at once
+func NewScheduler(targets []config.Target, check CheckFunc, concurrency int) *Scheduler {
+	return &Scheduler{
+		targets: targets,
+		check:   check,
+		slots:   make(chan struct{}, concurrency),
+		jitter:  randomJitter,
+	}
+}
+
+// Run checks each target once its jitter has passed, then every interval,
+// until ctx is cancelled. Checks in flight at that point are left to finish
+// and store their results before Run returns, unless ctx was cancelled
+// because the leader lease was lost: another replica is taking over, so they
+// are cancelled too.
+func (s *Scheduler) Run(ctx context.Context) {
+	log.Printf("Scheduling %d targets, at most %d checks at once", len(s.targets), cap(s.slots))
+
+	var wg sync.WaitGroup
+	for _, target := range s.targets {
+		wg.Add(1)
+		go func(target config.Target) {
+			defer wg.Done()
+			s.loop(ctx, target)
+		}(target)
+	}
+	wg.Wait()
+
+	log.Println("Scheduler stopped")
+}
+
+// loop runs one target's checks. The next check is due an interval after
+// the previous one started, so slow checks don't make the schedule drift.
+func (s *Scheduler) loop(ctx context.Context, target config.Target) {
+	interval := time.Duration(target.Interval)
+	timer := time.NewTimer(s.jitter(interval))
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		start := time.Now()
+		s.runCheck(ctx, target, interval)
+		timer.Reset(max(interval-time.Since(start)+s.jitter(interval), 0))
+	}
+}
+
+// runCheck waits for a free slot and checks the target. The check may take
+// up to its interval and isn't cancelled with ctx, so a shutdown doesn't cut
+// off a result before it's stored, except when the lease was lost.
+func (s *Scheduler) runCheck(ctx context.Context, target config.Target, interval time.Duration) {
+	select {
+	case s.slots <- struct{}{}:
+	case <-ctx.Done():
+		return
+	}
+	defer func() { <-s.slots }()
+
+	checkCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), interval)
+	defer cancel()
+	stop := context.AfterFunc(ctx, func() {
+		if errors.Is(context.Cause(ctx), leader.ErrLeaseLost) {
+			cancel()
+		}
+	})
+	defer stop()
+	s.check(checkCtx, target)
+}
+
+// randomJitter picks a delay between zero and maxJitter of the interval
+func randomJitter(interval time.Duration) time.Duration {
+	limit := int64(float64(interval) * maxJitter)
+	if limit <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int64N(limit))
+}
@@ -0,0 +1,140 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/config"
+	"github.com/openlearnnitj/openlearn-monitoring/internal/leader"
+)
+
+func newTestScheduler(targets []config.Target, check CheckFunc, concurrency int) *Scheduler {
+	s := NewScheduler(targets, check, concurrency)
+	s.jitter = func(time.Duration) time.Duration { return 0 }
+	return s
+}
+
+func TestSchedulerIntervals(t *testing.T) {
+	targets := []config.Target{
+		{Name: "fast", Interval: config.Duration(20 * time.Millisecond)},
+		{Name: "slow", Interval: config.Duration(100 * time.Millisecond)},
+	}
+
+	var mu sync.Mutex
+	runs := make(map[string]int)
+	s := newTestScheduler(targets, func(ctx context.Context, target config.Target) {
+		mu.Lock()
+		defer mu.Unlock()
+		runs[target.Name]++
+	}, 2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+	defer cancel()
+	s.Run(ctx)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if runs["fast"] < 8 || runs["fast"] > 14 {
+		t.Errorf("fast target ran %d times, want about 13", runs["fast"])
+	}
+	if runs["slow"] < 2 || runs["slow"] > 3 {
+		t.Errorf("slow target ran %d times, want 3", runs["slow"])
+	}
+}
+
+func TestSchedulerConcurrency(t *testing.T) {
+	var targets []config.Target
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		targets = append(targets, config.Target{Name: name, Interval: config.Duration(time.Hour)})
+	}
+
+	var running, peak, done atomic.Int32
+	s := newTestScheduler(targets, func(ctx context.Context, target config.Target) {
+		now := running.Add(1)
+		for {
+			old := peak.Load()
+			if now <= old || peak.CompareAndSwap(old, now) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		running.Add(-1)
+		done.Add(1)
+	}, 2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	s.Run(ctx)
+
+	if done.Load() != 5 {
+		t.Errorf("%d checks ran, want 5", done.Load())
+	}
+	if peak.Load() != 2 {
+		t.Errorf("%d checks ran at once, want 2", peak.Load())
+	}
+}
+
+func TestSchedulerShutdownFinishesChecks(t *testing.T) {
+	targets := []config.Target{{Name: "api", Interval: config.Duration(time.Hour)}}
+
+	started := make(chan struct{})
+	var finished atomic.Bool
+	s := newTestScheduler(targets, func(ctx context.Context, target config.Target) {
+		close(started)
+		time.Sleep(50 * time.Millisecond)
+		if ctx.Err() == nil {
+			finished.Store(true)
+		}
+	}, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		s.Run(ctx)
+		close(stopped)
+	}()
+
+	<-started
+	cancel()
+	<-stopped
+
+	if !finished.Load() {
+		t.Error("Run() returned before the in-flight check finished with a live context")
+	}
+}
+
+func TestSchedulerLostLeaseCancelsChecks(t *testing.T) {
+	targets := []config.Target{{Name: "api", Interval: config.Duration(time.Hour)}}
+
+	started := make(chan struct{})
+	var cancelled atomic.Bool
+	s := newTestScheduler(targets, func(ctx context.Context, target config.Target) {
+		close(started)
+		select {
+		case <-ctx.Done():
+			cancelled.Store(true)
+		case <-time.After(time.Second):
+		}
+	}, 1)
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		s.Run(ctx)
+		close(stopped)
+	}()
+
+	<-started
+	cancel(leader.ErrLeaseLost)
+	select {
+	case <-stopped:
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("Run() waited for the in-flight check after the lease was lost")
+	}
+	if !cancelled.Load() {
+		t.Error("in-flight check wasn't cancelled after the lease was lost")
+	}
+}
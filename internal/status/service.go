@@ -3,38 +3,47 @@ package status
 import (
 	"context"
 	"fmt"
-	"sort"
 	"time"
 
-	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
-	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/openlearnnitj/openlearn-monitoring/internal/models"
+	"github.com/openlearnnitj/openlearn-monitoring/internal/storage"
 )
 
 // StatusService handles status page data operations
 type StatusService struct {
-	client    *dynamodb.Client
-	tableName string
+	backend storage.Backend
+	now     func() time.Time
 }
 
 // NewStatusService creates a new status service
-func NewStatusService(client *dynamodb.Client, tableName string) *StatusService {
+func NewStatusService(backend storage.Backend) *StatusService {
 	return &StatusService{
-		client:    client,
-		tableName: tableName,
+		backend: backend,
+		now:     time.Now,
 	}
 }
 
+// SetClock replaces the time source used for uptime windows and history,
+// allowing tests to evaluate status at a fixed point in time
+func (s *StatusService) SetClock(now func() time.Time) {
+	s.now = now
+}
+
 // ComponentStatus represents the current status of a component
 type ComponentStatus struct {
-	Name                   string    `json:"name"`
-	Status                 string    `json:"status"`
-	InternalResponseTimeMs float64   `json:"internalResponseTimeMs"`
-	TotalResponseTimeMs    int64     `json:"totalResponseTimeMs"`
-	LastChecked            time.Time `json:"lastChecked"`
-	UptimePercent          float64   `json:"uptimePercent"`
-	StatusHistory          []StatusPoint `json:"statusHistory"`
+	Name                   string              `json:"name"`
+	Status                 string              `json:"status"`
+	InternalResponseTimeMs float64             `json:"internalResponseTimeMs"`
+	TotalResponseTimeMs    int64               `json:"totalResponseTimeMs"`
+	Message                string              `json:"message,omitempty"`
+	ErrorClass             string              `json:"errorClass,omitempty"`
+	Certificate            *models.Certificate `json:"certificate,omitempty"`
+	Steps                  []models.StepResult `json:"steps,omitempty"`
+	Attempts               []models.Attempt    `json:"attempts,omitempty"`
+	LastChecked            time.Time           `json:"lastChecked"`
+	UptimePercent          float64             `json:"uptimePercent"`
+	Uptime                 UptimeStats         `json:"uptime"`
+	StatusHistory          []StatusPoint       `json:"statusHistory"`
 }
 
 // StatusPoint represents a point in time status
@@ -58,42 +67,30 @@ type UptimeStats struct {
 	Last30Days  float64 `json:"last30Days"`
 }
 
+// historyDays is the number of days covered by the status history bars
+const historyDays = 90
+
+// uptimeWindow is the longest period covered by the uptime stats
+const uptimeWindow = 30 * 24 * time.Hour
+
+// rawWindow is how far back raw checks are always read; older periods are
+// served from rollups when they exist
+const rawWindow = 24 * time.Hour
+
+// componentHistory holds what is needed to compute a component's uptime and
+// status history: hourly and daily rollups, and the raw checks of every
+// period they don't cover
+type componentHistory struct {
+	raw    []models.CheckRecord
+	hourly []models.Rollup
+	daily  []models.Rollup
+}
+
 // GetCurrentStatus retrieves the current status of all components
 func (s *StatusService) GetCurrentStatus(ctx context.Context) (*SystemStatus, error) {
-	// Scan all items to get current status
-	result, err := s.client.Scan(ctx, &dynamodb.ScanInput{
-		TableName: aws.String(s.tableName),
-	})
+	latestRecords, err := s.backend.Latest(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to scan DynamoDB: %w", err)
-	}
-
-	// Group items by service name and get latest status
-	componentMap := make(map[string][]models.DynamoDBItem)
-	
-	for _, item := range result.Items {
-		var dbItem models.DynamoDBItem
-		
-		// Manual parsing since we need to handle the timestamp
-		if serviceName, ok := item["serviceName"].(*types.AttributeValueMemberS); ok {
-			dbItem.ServiceName = serviceName.Value
-		}
-		if status, ok := item["status"].(*types.AttributeValueMemberS); ok {
-			dbItem.Status = status.Value
-		}
-		if responseTime, ok := item["internalResponseTimeMs"].(*types.AttributeValueMemberN); ok {
-			fmt.Sscanf(responseTime.Value, "%f", &dbItem.InternalResponseTimeMs)
-		}
-		if totalTime, ok := item["totalResponseTimeMs"].(*types.AttributeValueMemberN); ok {
-			fmt.Sscanf(totalTime.Value, "%d", &dbItem.TotalResponseTimeMs)
-		}
-		if lastChecked, ok := item["lastChecked"].(*types.AttributeValueMemberS); ok {
-			if timestamp, err := time.Parse(time.RFC3339, lastChecked.Value); err == nil {
-				dbItem.LastChecked = timestamp.Format("2006-01-02T15:04:05Z07:00")
-			}
-		}
-		
-		componentMap[dbItem.ServiceName] = append(componentMap[dbItem.ServiceName], dbItem)
+		return nil, fmt.Errorf("failed to load latest status: %w", err)
 	}
 
 	// Process each component
@@ -101,42 +98,39 @@ func (s *StatusService) GetCurrentStatus(ctx context.Context) (*SystemStatus, er
 	overallOperational := true
 	lastUpdated := time.Time{}
 
-	for serviceName, items := range componentMap {
-		// Sort by timestamp to get latest status
-		sort.Slice(items, func(i, j int) bool {
-			ti, _ := time.Parse("2006-01-02T15:04:05Z07:00", items[i].LastChecked)
-			tj, _ := time.Parse("2006-01-02T15:04:05Z07:00", items[j].LastChecked)
-			return ti.After(tj)
-		})
-
-		if len(items) == 0 {
-			continue
+	for _, latest := range latestRecords {
+		history, err := s.loadHistory(ctx, latest.ServiceName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load history for %s: %w", latest.ServiceName, err)
 		}
 
-		latest := items[0]
-		lastChecked, _ := time.Parse("2006-01-02T15:04:05Z07:00", latest.LastChecked)
-		
-		if lastChecked.After(lastUpdated) {
-			lastUpdated = lastChecked
+		if latest.LastChecked.After(lastUpdated) {
+			lastUpdated = latest.LastChecked
 		}
 
 		// Calculate uptime for different periods
-		uptimeStats := s.calculateUptime(items)
-		
+		uptimeStats := s.calculateUptime(history)
+
 		// Generate status history for the last 90 days (like Anthropic's style)
-		statusHistory := s.generateStatusHistory(items, 90)
+		statusHistory := s.generateStatusHistory(history, historyDays)
 
 		component := ComponentStatus{
-			Name:                   serviceName,
+			Name:                   latest.ServiceName,
 			Status:                 latest.Status,
 			InternalResponseTimeMs: latest.InternalResponseTimeMs,
 			TotalResponseTimeMs:    latest.TotalResponseTimeMs,
-			LastChecked:            lastChecked,
+			Message:                latest.Message,
+			ErrorClass:             latest.ErrorClass,
+			Certificate:            latest.Certificate,
+			Steps:                  latest.Steps,
+			Attempts:               latest.Attempts,
+			LastChecked:            latest.LastChecked,
 			UptimePercent:          uptimeStats.Last24Hours,
+			Uptime:                 uptimeStats,
 			StatusHistory:          statusHistory,
 		}
 
-		if latest.Status != "OPERATIONAL" {
+		if latest.Status != models.StatusOperational {
 			overallOperational = false
 		}
 
@@ -146,9 +140,9 @@ func (s *StatusService) GetCurrentStatus(ctx context.Context) (*SystemStatus, er
 	// Calculate overall uptime stats
 	overallUptimeStats := s.calculateOverallUptime(components)
 
-	overallStatus := "OPERATIONAL"
+	overallStatus := models.StatusOperational
 	if !overallOperational {
-		overallStatus = "DEGRADED"
+		overallStatus = models.StatusDegraded
 	}
 
 	return &SystemStatus{
@@ -159,15 +153,90 @@ func (s *StatusService) GetCurrentStatus(ctx context.Context) (*SystemStatus, er
 	}, nil
 }
 
-// calculateUptime calculates uptime percentage for different time periods
-func (s *StatusService) calculateUptime(items []models.DynamoDBItem) UptimeStats {
-	now := time.Now()
-	
+// loadHistory reads a component's rollups and the raw checks they don't
+// cover. Raw checks are always read for the last day, and for older periods
+// without a rollup, e.g. from before rollups were introduced or whose rollup
+// failed to store.
+func (s *StatusService) loadHistory(ctx context.Context, serviceName string) (*componentHistory, error) {
+	now := s.now()
+	historyStart := now.AddDate(0, 0, -historyDays).Truncate(time.Hour)
+	hourlyStart := now.Add(-uptimeWindow).Truncate(time.Hour)
+	rawFrom := now.Add(-rawWindow).Truncate(time.Hour)
+
+	hourly, err := s.backend.Rollups(ctx, serviceName, models.GranularityHour, hourlyStart, now)
+	if err != nil {
+		return nil, err
+	}
+	daily, err := s.backend.Rollups(ctx, serviceName, models.GranularityDay, historyStart.Truncate(24*time.Hour), now)
+	if err != nil {
+		return nil, err
+	}
+
+	// Hourly rollups from rawFrom onwards would double count raw checks
+	for len(hourly) > 0 && !hourly[len(hourly)-1].Start.Before(rawFrom) {
+		hourly = hourly[:len(hourly)-1]
+	}
+
+	// Uptime is counted from hourly rollups and the bars from daily ones,
+	// falling back to hourly ones, so an hour needs raw checks if it's
+	// within the uptime window and not rolled up, or older and its day
+	// isn't rolled up
+	hours := make(map[int64]bool, len(hourly))
+	for _, rollup := range hourly {
+		hours[rollup.Start.Unix()] = true
+	}
+	days := make(map[int64]bool, len(daily))
+	for _, rollup := range daily {
+		days[rollup.Start.Unix()] = true
+	}
+	covered := func(hour time.Time) bool {
+		if !hour.Before(hourlyStart) {
+			return hours[hour.Unix()]
+		}
+		return days[hour.Truncate(24*time.Hour).Unix()]
+	}
+
+	var raw []models.CheckRecord
+	for hour := historyStart; hour.Before(rawFrom); hour = hour.Add(time.Hour) {
+		if covered(hour) {
+			continue
+		}
+		gapEnd := hour.Add(time.Hour)
+		for gapEnd.Before(rawFrom) && !covered(gapEnd) {
+			gapEnd = gapEnd.Add(time.Hour)
+		}
+
+		records, err := s.backend.Range(ctx, serviceName, hour, gapEnd.Add(-time.Nanosecond))
+		if err != nil {
+			return nil, err
+		}
+		raw = append(raw, records...)
+		hour = gapEnd
+	}
+
+	recent, err := s.backend.Range(ctx, serviceName, rawFrom, now)
+	if err != nil {
+		return nil, err
+	}
+	raw = append(raw, recent...)
+
+	return &componentHistory{
+		raw:    raw,
+		hourly: hourly,
+		daily:  daily,
+	}, nil
+}
+
+// calculateUptime calculates uptime percentage for different time periods,
+// combining hourly rollups with the raw checks that follow them
+func (s *StatusService) calculateUptime(history *componentHistory) UptimeStats {
+	now := s.now()
+
 	// Define time periods
 	periods := map[string]time.Duration{
 		"24h": 24 * time.Hour,
 		"7d":  7 * 24 * time.Hour,
-		"30d": 30 * 24 * time.Hour,
+		"30d": uptimeWindow,
 	}
 
 	stats := UptimeStats{}
@@ -177,14 +246,22 @@ func (s *StatusService) calculateUptime(items []models.DynamoDBItem) UptimeStats
 		total := 0
 		operational := 0
 
-		for _, item := range items {
-			timestamp, err := time.Parse("2006-01-02T15:04:05Z07:00", item.LastChecked)
-			if err != nil || timestamp.Before(cutoff) {
+		for _, rollup := range history.hourly {
+			if rollup.Start.Before(cutoff) {
+				continue
+			}
+
+			total += rollup.Checks
+			operational += rollup.Operational
+		}
+
+		for _, item := range history.raw {
+			if item.LastChecked.Before(cutoff) {
 				continue
 			}
 
 			total++
-			if item.Status == "OPERATIONAL" {
+			if item.Status == models.StatusOperational {
 				operational++
 			}
 		}
@@ -209,24 +286,46 @@ func (s *StatusService) calculateUptime(items []models.DynamoDBItem) UptimeStats
 	return stats
 }
 
-// generateStatusHistory generates a visual status history for the last N days
-func (s *StatusService) generateStatusHistory(items []models.DynamoDBItem, days int) []StatusPoint {
-	now := time.Now()
+// generateStatusHistory generates a visual status history for the last N
+// days. Days with a daily rollup use it; the rest fall back to hourly
+// rollups and raw checks.
+func (s *StatusService) generateStatusHistory(history *componentHistory, days int) []StatusPoint {
+	now := s.now().UTC()
 	cutoff := now.AddDate(0, 0, -days)
 
 	var points []StatusPoint
 
-	// Group by day and determine status for each day
-	dayMap := make(map[string][]models.DynamoDBItem)
+	// Determine the worst status seen on each day
+	dayStatus := make(map[string]string)
+	rolledUpDays := make(map[string]bool)
+	worsen := func(dayKey, status string) {
+		current, ok := dayStatus[dayKey]
+		if !ok {
+			current = models.StatusOperational
+		}
+		dayStatus[dayKey] = models.WorseStatus(current, status)
+	}
+
+	for _, rollup := range history.daily {
+		dayKey := rollup.Start.Format("2006-01-02")
+		worsen(dayKey, rollup.WorstStatus)
+		rolledUpDays[dayKey] = true
+	}
 
-	for _, item := range items {
-		timestamp, err := time.Parse("2006-01-02T15:04:05Z07:00", item.LastChecked)
-		if err != nil || timestamp.Before(cutoff) {
+	for _, rollup := range history.hourly {
+		if dayKey := rollup.Start.Format("2006-01-02"); !rolledUpDays[dayKey] {
+			worsen(dayKey, rollup.WorstStatus)
+		}
+	}
+
+	for _, item := range history.raw {
+		if item.LastChecked.Before(cutoff) {
 			continue
 		}
 
-		dayKey := timestamp.Format("2006-01-02")
-		dayMap[dayKey] = append(dayMap[dayKey], item)
+		if dayKey := item.LastChecked.Format("2006-01-02"); !rolledUpDays[dayKey] {
+			worsen(dayKey, item.Status)
+		}
 	}
 
 	// Generate points for each day
@@ -234,15 +333,9 @@ func (s *StatusService) generateStatusHistory(items []models.DynamoDBItem, days
 		day := now.AddDate(0, 0, -d)
 		dayKey := day.Format("2006-01-02")
 
-		status := "OPERATIONAL" // Default status
-		if dayItems, exists := dayMap[dayKey]; exists && len(dayItems) > 0 {
-			// Determine worst status for the day
-			for _, item := range dayItems {
-				if item.Status != "OPERATIONAL" {
-					status = item.Status
-					break
-				}
-			}
+		status := models.StatusOperational // Default status
+		if worst, exists := dayStatus[dayKey]; exists {
+			status = worst
 		}
 
 		points = append([]StatusPoint{{
@@ -267,11 +360,9 @@ func (s *StatusService) calculateOverallUptime(components []ComponentStatus) Upt
 	var total24h, total7d, total30d float64
 
 	for _, component := range components {
-		total24h += component.UptimePercent
-		// For now, using the same uptime for all periods
-		// In a real implementation, you'd calculate these separately
-		total7d += component.UptimePercent
-		total30d += component.UptimePercent
+		total24h += component.Uptime.Last24Hours
+		total7d += component.Uptime.Last7Days
+		total30d += component.Uptime.Last30Days
 	}
 
 	count := float64(len(components))
@@ -0,0 +1,364 @@
+package status
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/models"
+	"github.com/openlearnnitj/openlearn-monitoring/internal/storage"
+)
+
+var testNow = time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+func record(name, status string, age time.Duration) models.CheckRecord {
+	return models.CheckRecord{
+		ServiceName:            name,
+		Status:                 status,
+		InternalResponseTimeMs: 12.5,
+		TotalResponseTimeMs:    120,
+		LastChecked:            testNow.Add(-age),
+	}
+}
+
+func newTestService(t *testing.T, records ...models.CheckRecord) *StatusService {
+	t.Helper()
+
+	backend := storage.NewMemoryBackend()
+	if err := backend.Append(context.Background(), records); err != nil {
+		t.Fatalf("failed to seed backend: %v", err)
+	}
+
+	s := NewStatusService(backend)
+	s.SetClock(func() time.Time { return testNow })
+	return s
+}
+
+// rawHistory wraps raw checks in a history with no rollups
+func rawHistory(items []models.CheckRecord) *componentHistory {
+	return &componentHistory{raw: items}
+}
+
+func TestCalculateUptime(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []models.CheckRecord
+		want  UptimeStats
+	}{
+		{
+			name: "no data is treated as operational",
+			want: UptimeStats{Last24Hours: 100, Last7Days: 100, Last30Days: 100},
+		},
+		{
+			name: "all operational",
+			items: []models.CheckRecord{
+				record("api", "OPERATIONAL", time.Hour),
+				record("api", "OPERATIONAL", 2*time.Hour),
+			},
+			want: UptimeStats{Last24Hours: 100, Last7Days: 100, Last30Days: 100},
+		},
+		{
+			name: "outage within the last day",
+			items: []models.CheckRecord{
+				record("api", "OPERATIONAL", time.Hour),
+				record("api", "DOWN", 2*time.Hour),
+				record("api", "OPERATIONAL", 3*time.Hour),
+				record("api", "OPERATIONAL", 4*time.Hour),
+			},
+			want: UptimeStats{Last24Hours: 75, Last7Days: 75, Last30Days: 75},
+		},
+		{
+			name: "older outage only affects longer windows",
+			items: []models.CheckRecord{
+				record("api", "OPERATIONAL", time.Hour),
+				record("api", "DOWN", 3*24*time.Hour),
+				record("api", "DOWN", 10*24*time.Hour),
+				record("api", "OPERATIONAL", 11*24*time.Hour),
+			},
+			want: UptimeStats{Last24Hours: 100, Last7Days: 50, Last30Days: 50},
+		},
+		{
+			name: "checks outside every window are ignored",
+			items: []models.CheckRecord{
+				record("api", "DOWN", 45*24*time.Hour),
+			},
+			want: UptimeStats{Last24Hours: 100, Last7Days: 100, Last30Days: 100},
+		},
+	}
+
+	s := newTestService(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.calculateUptime(rawHistory(tt.items)); got != tt.want {
+				t.Errorf("calculateUptime() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateStatusHistory(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []models.CheckRecord
+		days  int
+		want  map[int]string // days ago -> expected status
+	}{
+		{
+			name: "days without data default to operational",
+			days: 7,
+			want: map[int]string{0: "OPERATIONAL", 6: "OPERATIONAL"},
+		},
+		{
+			name: "a single failure marks the whole day",
+			items: []models.CheckRecord{
+				record("api", "OPERATIONAL", time.Hour),
+				record("api", "DEGRADED", 2*time.Hour),
+				record("api", "OPERATIONAL", 2*24*time.Hour),
+			},
+			days: 7,
+			want: map[int]string{0: "DEGRADED", 1: "OPERATIONAL", 2: "OPERATIONAL"},
+		},
+		{
+			name: "worst status of the day wins",
+			items: []models.CheckRecord{
+				record("api", "DEGRADED", time.Hour),
+				record("api", "DOWN", 2*time.Hour),
+				record("api", "DEGRADED", 3*time.Hour),
+			},
+			days: 7,
+			want: map[int]string{0: "DOWN"},
+		},
+		{
+			name: "failures before the window are dropped",
+			items: []models.CheckRecord{
+				record("api", "DOWN", 10*24*time.Hour),
+			},
+			days: 7,
+			want: map[int]string{0: "OPERATIONAL", 6: "OPERATIONAL"},
+		},
+	}
+
+	s := newTestService(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			points := s.generateStatusHistory(rawHistory(tt.items), tt.days)
+			if len(points) != tt.days {
+				t.Fatalf("got %d points, want %d", len(points), tt.days)
+			}
+
+			// Points are ordered oldest first, ending today
+			if last := points[len(points)-1].Timestamp; last.Format("2006-01-02") != testNow.Format("2006-01-02") {
+				t.Errorf("last point is %s, want today", last.Format("2006-01-02"))
+			}
+
+			for daysAgo, want := range tt.want {
+				if got := points[len(points)-1-daysAgo].Status; got != want {
+					t.Errorf("status %d days ago = %s, want %s", daysAgo, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestGetCurrentStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		records     []models.CheckRecord
+		wantOverall string
+		wantLatest  map[string]string
+	}{
+		{
+			name:        "empty backend",
+			wantOverall: "OPERATIONAL",
+			wantLatest:  map[string]string{},
+		},
+		{
+			name: "all components operational",
+			records: []models.CheckRecord{
+				record("api", "OPERATIONAL", time.Minute),
+				record("database", "OPERATIONAL", time.Minute),
+			},
+			wantOverall: "OPERATIONAL",
+			wantLatest:  map[string]string{"api": "OPERATIONAL", "database": "OPERATIONAL"},
+		},
+		{
+			name: "latest check decides component status",
+			records: []models.CheckRecord{
+				record("api", "DOWN", 2*time.Minute),
+				record("api", "OPERATIONAL", time.Minute),
+				record("database", "OPERATIONAL", 2*time.Minute),
+				record("database", "DOWN", time.Minute),
+			},
+			wantOverall: "DEGRADED",
+			wantLatest:  map[string]string{"api": "OPERATIONAL", "database": "DOWN"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(t, tt.records...)
+
+			got, err := s.GetCurrentStatus(context.Background())
+			if err != nil {
+				t.Fatalf("GetCurrentStatus() error = %v", err)
+			}
+
+			if got.OverallStatus != tt.wantOverall {
+				t.Errorf("OverallStatus = %s, want %s", got.OverallStatus, tt.wantOverall)
+			}
+			if len(got.Components) != len(tt.wantLatest) {
+				t.Fatalf("got %d components, want %d", len(got.Components), len(tt.wantLatest))
+			}
+			for _, component := range got.Components {
+				if want := tt.wantLatest[component.Name]; component.Status != want {
+					t.Errorf("%s status = %s, want %s", component.Name, component.Status, want)
+				}
+				if len(component.StatusHistory) != historyDays {
+					t.Errorf("%s has %d history points, want %d", component.Name, len(component.StatusHistory), historyDays)
+				}
+			}
+		})
+	}
+}
+
+func TestGetCurrentStatusUptime(t *testing.T) {
+	s := newTestService(t,
+		record("api", "OPERATIONAL", time.Hour),
+		record("api", "DOWN", 2*time.Hour),
+		record("api", "OPERATIONAL", 3*24*time.Hour),
+		record("api", "OPERATIONAL", 4*24*time.Hour),
+		record("database", "OPERATIONAL", time.Hour),
+	)
+
+	got, err := s.GetCurrentStatus(context.Background())
+	if err != nil {
+		t.Fatalf("GetCurrentStatus() error = %v", err)
+	}
+
+	want := UptimeStats{Last24Hours: 75, Last7Days: 87.5, Last30Days: 87.5}
+	if got.UptimeStats != want {
+		t.Errorf("UptimeStats = %+v, want %+v", got.UptimeStats, want)
+	}
+	if !got.LastUpdated.Equal(testNow.Add(-time.Hour)) {
+		t.Errorf("LastUpdated = %s, want %s", got.LastUpdated, testNow.Add(-time.Hour))
+	}
+}
+
+func rollup(granularity string, start time.Time, checks, operational int, worst string) models.Rollup {
+	return models.Rollup{
+		ServiceName: "api",
+		Granularity: granularity,
+		Start:       start,
+		Checks:      checks,
+		Operational: operational,
+		WorstStatus: worst,
+	}
+}
+
+func TestCalculateUptimeWithRollups(t *testing.T) {
+	hour := testNow.Truncate(time.Hour)
+	history := &componentHistory{
+		raw: []models.CheckRecord{
+			record("api", "OPERATIONAL", time.Hour),
+			record("api", "DOWN", 2*time.Hour),
+		},
+		hourly: []models.Rollup{
+			rollup(models.GranularityHour, hour.Add(-48*time.Hour), 60, 30, "DOWN"),
+			rollup(models.GranularityHour, hour.Add(-20*24*time.Hour), 60, 60, "OPERATIONAL"),
+		},
+	}
+
+	got := newTestService(t).calculateUptime(history)
+	want := UptimeStats{
+		Last24Hours: 50,                      // 1 of 2 raw checks
+		Last7Days:   float64(31) / 62 * 100,  // raw + the 48h-old rollup
+		Last30Days:  float64(91) / 122 * 100, // raw + both rollups
+	}
+	if got != want {
+		t.Errorf("calculateUptime() = %+v, want %+v", got, want)
+	}
+}
+
+func TestGetCurrentStatusUsesRollups(t *testing.T) {
+	backend := storage.NewMemoryBackend()
+	backend.SetClock(func() time.Time { return testNow })
+	ctx := context.Background()
+
+	day := testNow.Truncate(24 * time.Hour)
+	hour := testNow.Truncate(time.Hour)
+
+	// Raw checks cover the last day; the rollup inside that window must not
+	// be counted twice, while older periods come from rollups only
+	backend.Append(ctx, []models.CheckRecord{
+		record("api", "OPERATIONAL", time.Hour),
+		record("api", "OPERATIONAL", 30*time.Minute),
+	})
+	backend.PutRollups(ctx, []models.Rollup{
+		rollup(models.GranularityHour, hour.Add(-2*time.Hour), 60, 60, "OPERATIONAL"),
+		rollup(models.GranularityHour, hour.Add(-3*24*time.Hour), 60, 0, "DOWN"),
+		rollup(models.GranularityDay, day.Add(-3*24*time.Hour), 1440, 1380, "DOWN"),
+		rollup(models.GranularityDay, day.Add(-60*24*time.Hour), 1440, 1439, "DEGRADED"),
+	})
+
+	s := NewStatusService(backend)
+	s.SetClock(func() time.Time { return testNow })
+
+	got, err := s.GetCurrentStatus(ctx)
+	if err != nil {
+		t.Fatalf("GetCurrentStatus() error = %v", err)
+	}
+	if len(got.Components) != 1 {
+		t.Fatalf("got %d components, want 1", len(got.Components))
+	}
+	api := got.Components[0]
+
+	if want := float64(2) / 62 * 100; api.Uptime.Last7Days != want {
+		t.Errorf("Last7Days = %v, want %v", api.Uptime.Last7Days, want)
+	}
+
+	history := api.StatusHistory
+	for daysAgo, want := range map[int]string{0: "OPERATIONAL", 3: "DOWN", 60: "DEGRADED", 59: "OPERATIONAL"} {
+		if got := history[len(history)-1-daysAgo].Status; got != want {
+			t.Errorf("status %d days ago = %s, want %s", daysAgo, got, want)
+		}
+	}
+}
+
+func TestGetCurrentStatusFillsRollupGaps(t *testing.T) {
+	backend := storage.NewMemoryBackend()
+	backend.SetClock(func() time.Time { return testNow })
+	ctx := context.Background()
+	hour := testNow.Truncate(time.Hour)
+
+	// Checks from before rollups were introduced have none, while recent
+	// periods do; the old checks must still count
+	backend.Append(ctx, []models.CheckRecord{
+		record("api", "DOWN", 5*24*time.Hour),
+		record("api", "OPERATIONAL", 40*24*time.Hour),
+		record("api", "DEGRADED", 45*24*time.Hour),
+		record("api", "OPERATIONAL", 30*time.Minute),
+	})
+	backend.PutRollups(ctx, []models.Rollup{
+		rollup(models.GranularityHour, hour.Add(-2*24*time.Hour), 60, 60, "OPERATIONAL"),
+		rollup(models.GranularityDay, testNow.Truncate(24*time.Hour).Add(-40*24*time.Hour), 1440, 1440, "OPERATIONAL"),
+	})
+
+	s := NewStatusService(backend)
+	s.SetClock(func() time.Time { return testNow })
+
+	got, err := s.GetCurrentStatus(ctx)
+	if err != nil {
+		t.Fatalf("GetCurrentStatus() error = %v", err)
+	}
+	api := got.Components[0]
+
+	if want := float64(61) / 62 * 100; api.Uptime.Last7Days != want {
+		t.Errorf("Last7Days = %v, want %v", api.Uptime.Last7Days, want)
+	}
+	history := api.StatusHistory
+	for daysAgo, want := range map[int]string{0: "OPERATIONAL", 5: "DOWN", 40: "OPERATIONAL", 45: "DEGRADED"} {
+		if got := history[len(history)-1-daysAgo].Status; got != want {
+			t.Errorf("status %d days ago = %s, want %s", daysAgo, got, want)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/config"
+	"github.com/openlearnnitj/openlearn-monitoring/internal/models"
+)
+
+// Backend is implemented by every check history store
+type Backend interface {
+	// Append stores the given check records. A record checked at the same
+	// time as a stored record of its component is a duplicate write and is
+	// skipped.
+	Append(ctx context.Context, records []models.CheckRecord) error
+
+	// Latest returns the most recent record of every known component
+	Latest(ctx context.Context) ([]models.CheckRecord, error)
+
+	// Last returns the most recent record of one component, reporting false
+	// if it has none
+	Last(ctx context.Context, serviceName string) (models.CheckRecord, bool, error)
+
+	// Range returns the records of a component checked between from and to
+	// (inclusive), oldest first
+	Range(ctx context.Context, serviceName string, from, to time.Time) ([]models.CheckRecord, error)
+
+	// PutRollups stores aggregates, replacing any existing rollup of the same
+	// component, granularity and start
+	PutRollups(ctx context.Context, rollups []models.Rollup) error
+
+	// Rollups returns a component's aggregates of one granularity whose
+	// period starts between from and to (inclusive), oldest first
+	Rollups(ctx context.Context, serviceName, granularity string, from, to time.Time) ([]models.Rollup, error)
+
+	// LastRollup returns a component's aggregate of one granularity with the
+	// latest start, reporting false if it has none
+	LastRollup(ctx context.Context, serviceName, granularity string) (models.Rollup, bool, error)
+}
+
+// NewBackend creates the storage backend selected in the configuration
+func NewBackend(cfg *config.Config) (Backend, error) {
+	switch cfg.StorageBackend {
+	case config.BackendDynamoDB:
+		client, err := NewDynamoDBClient(cfg.AWSRegion)
+		if err != nil {
+			return nil, err
+		}
+		return NewDynamoDBBackend(client, cfg.DynamoDBTableName), nil
+	case config.BackendFile:
+		return NewFileBackend(cfg.StorageFilePath)
+	default:
+		return nil, fmt.Errorf("unsupported storage backend %q", cfg.StorageBackend)
+	}
+}
@@ -0,0 +1,38 @@
+package storage
+
+import (
+	"fmt"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/models"
+)
+
+// ConfirmFailures counts the consecutive checks each component of a result
+// has been DOWN, continuing the streak of its latest stored check in
+// previous (as read by LatestStored), so checks stored by other processes
+// count. Until a component has been DOWN for confirmAfter checks in a row it
+// keeps the status it was last recorded with, OPERATIONAL if it's new, and
+// the unconfirmed failure is noted in its message.
+func ConfirmFailures(result *models.MonitoringResult, previous map[string]models.CheckRecord, confirmAfter int) {
+	for i := range result.Components {
+		component := &result.Components[i]
+		if component.Status != models.StatusDown {
+			component.Failures = 0
+			continue
+		}
+
+		last := previous[component.Name]
+		component.Failures = last.Failures + 1
+
+		if component.Failures < confirmAfter && last.Status != models.StatusDown {
+			note := fmt.Sprintf("unconfirmed failure %d of %d", component.Failures, confirmAfter)
+			if component.Message != "" {
+				note += ": " + component.Message
+			}
+			component.Message = note
+			component.Status = last.Status
+			if component.Status == "" {
+				component.Status = models.StatusOperational
+			}
+		}
+	}
+}
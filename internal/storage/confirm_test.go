@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/models"
+)
+
+// confirmAndStore confirms the failures of a result against its stored
+// checks and stores it, as the handler does
+func confirmAndStore(t *testing.T, service *Service, result *models.MonitoringResult, confirmAfter int) {
+	t.Helper()
+	previous, err := service.LatestStored(context.Background(), result)
+	if err != nil {
+		t.Fatalf("LatestStored() error = %v", err)
+	}
+	ConfirmFailures(result, previous, confirmAfter)
+	if err := service.StoreResultsWithPrevious(context.Background(), result, previous); err != nil {
+		t.Fatalf("StoreResultsWithPrevious() error = %v", err)
+	}
+}
+
+func TestConfirmFailures(t *testing.T) {
+	service := NewService(NewMemoryBackend(), 0)
+
+	tests := []struct {
+		status       string
+		wantStatus   string
+		wantFailures int
+	}{
+		{"OPERATIONAL", "OPERATIONAL", 0},
+		{"DOWN", "OPERATIONAL", 1},
+		{"DOWN", "OPERATIONAL", 2},
+		{"DOWN", "DOWN", 3},
+		{"DOWN", "DOWN", 4},
+		{"DEGRADED", "DEGRADED", 0},
+		{"DOWN", "DEGRADED", 1},
+	}
+
+	for i, tt := range tests {
+		result := &models.MonitoringResult{
+			Components: []models.Component{{Name: "api", Status: tt.status, Message: "connection refused"}},
+			Timestamp:  testNow.Add(time.Duration(i) * time.Minute),
+		}
+		confirmAndStore(t, service, result, 3)
+
+		component := result.Components[0]
+		if component.Status != tt.wantStatus || component.Failures != tt.wantFailures {
+			t.Errorf("check %d: got %s with %d failures, want %s with %d",
+				i+1, component.Status, component.Failures, tt.wantStatus, tt.wantFailures)
+		}
+		unconfirmed := strings.HasPrefix(component.Message, "unconfirmed failure")
+		if unconfirmed != (tt.status != tt.wantStatus) {
+			t.Errorf("check %d: Message = %q", i+1, component.Message)
+		}
+	}
+}
+
+func TestConfirmFailuresReadsStoredStreak(t *testing.T) {
+	backend := NewMemoryBackend()
+	err := backend.Append(context.Background(), []models.CheckRecord{
+		{ServiceName: "api", Status: "OPERATIONAL", Failures: 1, LastChecked: testNow.Add(-time.Minute)},
+	})
+	if err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+
+	// A new process continues the streak recorded by the previous one
+	service := NewService(backend, 0)
+	result := &models.MonitoringResult{
+		Components: []models.Component{{Name: "api", Status: "DOWN"}},
+		Timestamp:  testNow,
+	}
+	confirmAndStore(t, service, result, 2)
+	if got := result.Components[0]; got.Status != "DOWN" || got.Failures != 2 {
+		t.Errorf("component = %+v, want DOWN after 2 failures", got)
+	}
+
+	latest, _ := backend.Latest(context.Background())
+	if len(latest) != 1 || latest[0].Failures != 2 {
+		t.Errorf("Latest() = %+v, want the failure streak stored", latest)
+	}
+}
+
+func TestConfirmFailuresAcrossWriters(t *testing.T) {
+	backend := NewMemoryBackend()
+	services := []*Service{NewService(backend, 0), NewService(backend, 0)}
+
+	// Processes taking turns continue one streak
+	for i, want := range []string{"OPERATIONAL", "OPERATIONAL", "DOWN"} {
+		service := services[i%2]
+		result := &models.MonitoringResult{
+			Components: []models.Component{{Name: "api", Status: "DOWN"}},
+			Timestamp:  testNow.Add(time.Duration(i) * time.Minute),
+		}
+		confirmAndStore(t, service, result, 3)
+		if got := result.Components[0]; got.Status != want || got.Failures != i+1 {
+			t.Errorf("check %d: got %s with %d failures, want %s with %d", i+1, got.Status, got.Failures, want, i+1)
+		}
+	}
+}
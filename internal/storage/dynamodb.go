@@ -2,8 +2,14 @@ package storage
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
+	"sort"
+	"strconv"
 	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
@@ -34,38 +40,45 @@ func NewDynamoDBClient(region string) (*DynamoDBClient, error) {
 	}, nil
 }
 
-// Service handles storage operations
-type Service struct {
+// registryKey is the serviceName of the item that lists every component
+// written to the table. Component names starting with '#' are reserved.
+const registryKey = "#components"
+
+// leaseKey is the serviceName of lease items, whose lastChecked is the
+// lease name
+const leaseKey = "#lease"
+
+// DynamoDBBackend stores check history in a DynamoDB table
+type DynamoDBBackend struct {
 	client    *DynamoDBClient
 	tableName string
+
+	// registered caches component names already present in the registry
+	registered sync.Map
 }
 
-// NewService creates a new storage service
-func NewService(client *DynamoDBClient, tableName string) *Service {
-	return &Service{
+// NewDynamoDBBackend creates a new DynamoDB storage backend
+func NewDynamoDBBackend(client *DynamoDBClient, tableName string) *DynamoDBBackend {
+	return &DynamoDBBackend{
 		client:    client,
 		tableName: tableName,
 	}
 }
 
-// StoreResults stores monitoring results concurrently in DynamoDB
-func (s *Service) StoreResults(ctx context.Context, result *models.MonitoringResult) error {
-	if len(result.Components) == 0 {
-		return fmt.Errorf("no components to store")
-	}
-
+// Append stores check records concurrently in DynamoDB
+func (d *DynamoDBBackend) Append(ctx context.Context, records []models.CheckRecord) error {
 	// Use goroutines for concurrent writes
 	var wg sync.WaitGroup
-	errCh := make(chan error, len(result.Components))
+	errCh := make(chan error, len(records))
 
-	for _, component := range result.Components {
+	for _, record := range records {
 		wg.Add(1)
-		go func(comp models.Component) {
+		go func(rec models.CheckRecord) {
 			defer wg.Done()
-			if err := s.storeComponent(ctx, comp, result); err != nil {
+			if err := d.storeComponent(ctx, rec); err != nil {
 				errCh <- err
 			}
-		}(component)
+		}(record)
 	}
 
 	// Wait for all goroutines to complete
@@ -82,37 +95,520 @@ func (s *Service) StoreResults(ctx context.Context, result *models.MonitoringRes
 		return fmt.Errorf("failed to store %d components: %v", len(errors), errors)
 	}
 
+	return d.register(ctx, records)
+}
+
+// register adds any component names not seen before to the registry item,
+// which lets Latest find every component without scanning the table
+func (d *DynamoDBBackend) register(ctx context.Context, records []models.CheckRecord) error {
+	var names []string
+	for _, record := range records {
+		if _, ok := d.registered.Load(record.ServiceName); !ok {
+			names = append(names, record.ServiceName)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	_, err := d.client.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:        aws.String(d.tableName),
+		Key:              registryItemKey(),
+		UpdateExpression: aws.String("ADD components :names"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":names": &types.AttributeValueMemberSS{Value: names},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register components: %w", err)
+	}
+
+	for _, name := range names {
+		d.registered.Store(name, struct{}{})
+	}
 	return nil
 }
 
-// storeComponent stores a single component in DynamoDB
-func (s *Service) storeComponent(ctx context.Context, component models.Component, result *models.MonitoringResult) error {
+// components reads the names of every registered component
+func (d *DynamoDBBackend) components(ctx context.Context) ([]string, error) {
+	result, err := d.client.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(d.tableName),
+		Key:       registryItemKey(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read component registry: %w", err)
+	}
+
+	names, ok := result.Item["components"].(*types.AttributeValueMemberSS)
+	if !ok {
+		return nil, nil
+	}
+	return names.Value, nil
+}
+
+// registryItemKey returns the primary key of the component registry item
+func registryItemKey() map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"serviceName": &types.AttributeValueMemberS{Value: registryKey},
+		"lastChecked": &types.AttributeValueMemberS{Value: registryKey},
+	}
+}
+
+// storeComponent appends a single component check to DynamoDB. Items are keyed
+// by serviceName and lastChecked, so every check is kept as its own row rather
+// than overwriting the previous one.
+func (d *DynamoDBBackend) storeComponent(ctx context.Context, record models.CheckRecord) error {
 	item := map[string]types.AttributeValue{
 		"serviceName": &types.AttributeValueMemberS{
-			Value: component.Name,
+			Value: record.ServiceName,
 		},
 		"status": &types.AttributeValueMemberS{
-			Value: component.Status,
+			Value: record.Status,
 		},
 		"internalResponseTimeMs": &types.AttributeValueMemberN{
-			Value: fmt.Sprintf("%.2f", component.ResponseTimeMs),
+			Value: fmt.Sprintf("%.2f", record.InternalResponseTimeMs),
 		},
 		"totalResponseTimeMs": &types.AttributeValueMemberN{
-			Value: fmt.Sprintf("%d", result.TotalResponseTimeMs),
+			Value: fmt.Sprintf("%d", record.TotalResponseTimeMs),
 		},
 		"lastChecked": &types.AttributeValueMemberS{
-			Value: result.Timestamp.Format("2006-01-02T15:04:05Z07:00"),
+			Value: record.LastChecked.UTC().Format(time.RFC3339),
 		},
 	}
 
-	_, err := s.client.client.PutItem(ctx, &dynamodb.PutItemInput{
-		TableName: aws.String(s.tableName),
+	if record.Message != "" {
+		item["message"] = &types.AttributeValueMemberS{
+			Value: record.Message,
+		}
+	}
+	if record.ErrorClass != "" {
+		item["errorClass"] = &types.AttributeValueMemberS{
+			Value: record.ErrorClass,
+		}
+	}
+	if record.Certificate != nil {
+		certificate, err := json.Marshal(record.Certificate)
+		if err != nil {
+			return fmt.Errorf("failed to encode certificate of %s: %w", record.ServiceName, err)
+		}
+		item["certificate"] = &types.AttributeValueMemberS{
+			Value: string(certificate),
+		}
+	}
+	if len(record.Steps) > 0 {
+		steps, err := json.Marshal(record.Steps)
+		if err != nil {
+			return fmt.Errorf("failed to encode steps of %s: %w", record.ServiceName, err)
+		}
+		item["steps"] = &types.AttributeValueMemberS{
+			Value: string(steps),
+		}
+	}
+	if len(record.Attempts) > 0 {
+		attempts, err := json.Marshal(record.Attempts)
+		if err != nil {
+			return fmt.Errorf("failed to encode attempts of %s: %w", record.ServiceName, err)
+		}
+		item["attempts"] = &types.AttributeValueMemberS{
+			Value: string(attempts),
+		}
+	}
+	if record.Failures > 0 {
+		item["failures"] = &types.AttributeValueMemberN{
+			Value: strconv.Itoa(record.Failures),
+		}
+	}
+	if !record.StatusSince.IsZero() {
+		item["statusSince"] = &types.AttributeValueMemberS{
+			Value: record.StatusSince.UTC().Format(time.RFC3339),
+		}
+	}
+
+	// DynamoDB TTL deletes the item once expiresAt (epoch seconds) has passed
+	if !record.ExpiresAt.IsZero() {
+		item["expiresAt"] = &types.AttributeValueMemberN{
+			Value: strconv.FormatInt(record.ExpiresAt.Unix(), 10),
+		}
+	}
+
+	// A check already stored for the same time is a duplicate write, e.g.
+	// from a retried invocation, and is kept as it is
+	_, err := d.client.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(d.tableName),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(lastChecked)"),
+	})
+
+	var conditionErr *types.ConditionalCheckFailedException
+	if errors.As(err, &conditionErr) {
+		log.Printf("Skipping duplicate check of %s at %s", record.ServiceName, record.LastChecked.UTC().Format(time.RFC3339))
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to store component %s: %w", record.ServiceName, err)
+	}
+
+	return nil
+}
+
+// Latest returns the most recent record of every registered component. Each
+// component costs a single one-item Query, so this stays flat as history grows.
+func (d *DynamoDBBackend) Latest(ctx context.Context) ([]models.CheckRecord, error) {
+	names, err := d.components(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		records []models.CheckRecord
+		errors  []error
+	)
+
+	for _, name := range names {
+		wg.Add(1)
+		go func(serviceName string) {
+			defer wg.Done()
+
+			record, ok, err := d.last(ctx, serviceName, false)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errors = append(errors, fmt.Errorf("%s: %w", serviceName, err))
+				return
+			}
+			if ok {
+				records = append(records, record)
+			}
+		}(name)
+	}
+
+	wg.Wait()
+
+	if len(errors) > 0 {
+		return nil, fmt.Errorf("failed to query latest status of %d components: %v", len(errors), errors)
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].ServiceName < records[j].ServiceName
+	})
+
+	return records, nil
+}
+
+// Last queries the most recent record of a single component. The read is
+// strongly consistent, so a check just stored by another writer is seen.
+func (d *DynamoDBBackend) Last(ctx context.Context, serviceName string) (models.CheckRecord, bool, error) {
+	return d.last(ctx, serviceName, true)
+}
+
+// last queries the most recent record of a component with a one-item Query
+func (d *DynamoDBBackend) last(ctx context.Context, serviceName string, consistent bool) (models.CheckRecord, bool, error) {
+	result, err := d.client.client.Query(ctx, &dynamodb.QueryInput{
+		TableName:              aws.String(d.tableName),
+		KeyConditionExpression: aws.String("serviceName = :name"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":name": &types.AttributeValueMemberS{Value: serviceName},
+		},
+		ScanIndexForward: aws.Bool(false),
+		Limit:            aws.Int32(1),
+		ConsistentRead:   aws.Bool(consistent),
+	})
+	if err != nil {
+		return models.CheckRecord{}, false, fmt.Errorf("failed to query latest check of %s: %w", serviceName, err)
+	}
+	if len(result.Items) == 0 {
+		return models.CheckRecord{}, false, nil
+	}
+	return parseItem(result.Items[0]), true, nil
+}
+
+// Range queries the records of a single component between from and to,
+// following LastEvaluatedKey until the window is exhausted
+func (d *DynamoDBBackend) Range(ctx context.Context, serviceName string, from, to time.Time) ([]models.CheckRecord, error) {
+	paginator := dynamodb.NewQueryPaginator(d.client.client, &dynamodb.QueryInput{
+		TableName:              aws.String(d.tableName),
+		KeyConditionExpression: aws.String("serviceName = :name AND lastChecked BETWEEN :from AND :to"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":name": &types.AttributeValueMemberS{Value: serviceName},
+			":from": &types.AttributeValueMemberS{Value: from.UTC().Format(time.RFC3339)},
+			":to":   &types.AttributeValueMemberS{Value: to.UTC().Format(time.RFC3339)},
+		},
+	})
+
+	var records []models.CheckRecord
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query DynamoDB for %s: %w", serviceName, err)
+		}
+		for _, item := range page.Items {
+			records = append(records, parseItem(item))
+		}
+	}
+
+	return records, nil
+}
+
+// parseItem converts a DynamoDB item into a check record
+func parseItem(item map[string]types.AttributeValue) models.CheckRecord {
+	var record models.CheckRecord
+
+	if serviceName, ok := item["serviceName"].(*types.AttributeValueMemberS); ok {
+		record.ServiceName = serviceName.Value
+	}
+	if status, ok := item["status"].(*types.AttributeValueMemberS); ok {
+		record.Status = status.Value
+	}
+	if responseTime, ok := item["internalResponseTimeMs"].(*types.AttributeValueMemberN); ok {
+		fmt.Sscanf(responseTime.Value, "%f", &record.InternalResponseTimeMs)
+	}
+	if totalTime, ok := item["totalResponseTimeMs"].(*types.AttributeValueMemberN); ok {
+		fmt.Sscanf(totalTime.Value, "%d", &record.TotalResponseTimeMs)
+	}
+	if message, ok := item["message"].(*types.AttributeValueMemberS); ok {
+		record.Message = message.Value
+	}
+	if errorClass, ok := item["errorClass"].(*types.AttributeValueMemberS); ok {
+		record.ErrorClass = errorClass.Value
+	}
+	if certificate, ok := item["certificate"].(*types.AttributeValueMemberS); ok {
+		json.Unmarshal([]byte(certificate.Value), &record.Certificate)
+	}
+	if steps, ok := item["steps"].(*types.AttributeValueMemberS); ok {
+		json.Unmarshal([]byte(steps.Value), &record.Steps)
+	}
+	if attempts, ok := item["attempts"].(*types.AttributeValueMemberS); ok {
+		json.Unmarshal([]byte(attempts.Value), &record.Attempts)
+	}
+	if failures, ok := item["failures"].(*types.AttributeValueMemberN); ok {
+		record.Failures, _ = strconv.Atoi(failures.Value)
+	}
+	if statusSince, ok := item["statusSince"].(*types.AttributeValueMemberS); ok {
+		if timestamp, err := time.Parse(time.RFC3339, statusSince.Value); err == nil {
+			record.StatusSince = timestamp
+		}
+	}
+	if lastChecked, ok := item["lastChecked"].(*types.AttributeValueMemberS); ok {
+		if timestamp, err := time.Parse(time.RFC3339, lastChecked.Value); err == nil {
+			record.LastChecked = timestamp
+		}
+	}
+	if expiresAt, ok := item["expiresAt"].(*types.AttributeValueMemberN); ok {
+		if seconds, err := strconv.ParseInt(expiresAt.Value, 10, 64); err == nil {
+			record.ExpiresAt = time.Unix(seconds, 0).UTC()
+		}
+	}
+
+	return record
+}
+
+// PutRollups stores aggregates concurrently in DynamoDB. Rollups live in
+// their own partition per component and granularity ("<name>#hour") keyed by
+// period start, so they never mix with raw checks.
+func (d *DynamoDBBackend) PutRollups(ctx context.Context, rollups []models.Rollup) error {
+	var wg sync.WaitGroup
+	errCh := make(chan error, len(rollups))
+
+	for _, rollup := range rollups {
+		wg.Add(1)
+		go func(r models.Rollup) {
+			defer wg.Done()
+			if err := d.storeRollup(ctx, r); err != nil {
+				errCh <- err
+			}
+		}(rollup)
+	}
+
+	wg.Wait()
+	close(errCh)
+
+	var errors []error
+	for err := range errCh {
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return fmt.Errorf("failed to store %d rollups: %v", len(errors), errors)
+	}
+
+	return nil
+}
+
+// storeRollup writes a single rollup item
+func (d *DynamoDBBackend) storeRollup(ctx context.Context, rollup models.Rollup) error {
+	item := map[string]types.AttributeValue{
+		"serviceName":       &types.AttributeValueMemberS{Value: rollupKey(rollup.ServiceName, rollup.Granularity)},
+		"lastChecked":       &types.AttributeValueMemberS{Value: rollup.Start.UTC().Format(time.RFC3339)},
+		"checks":            &types.AttributeValueMemberN{Value: strconv.Itoa(rollup.Checks)},
+		"operational":       &types.AttributeValueMemberN{Value: strconv.Itoa(rollup.Operational)},
+		"worstStatus":       &types.AttributeValueMemberS{Value: rollup.WorstStatus},
+		"minResponseTimeMs": &types.AttributeValueMemberN{Value: fmt.Sprintf("%.2f", rollup.MinResponseTimeMs)},
+		"avgResponseTimeMs": &types.AttributeValueMemberN{Value: fmt.Sprintf("%.2f", rollup.AvgResponseTimeMs)},
+		"maxResponseTimeMs": &types.AttributeValueMemberN{Value: fmt.Sprintf("%.2f", rollup.MaxResponseTimeMs)},
+		"p95ResponseTimeMs": &types.AttributeValueMemberN{Value: fmt.Sprintf("%.2f", rollup.P95ResponseTimeMs)},
+	}
+	if !rollup.ExpiresAt.IsZero() {
+		item["expiresAt"] = &types.AttributeValueMemberN{
+			Value: strconv.FormatInt(rollup.ExpiresAt.Unix(), 10),
+		}
+	}
+
+	_, err := d.client.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(d.tableName),
 		Item:      item,
 	})
+	if err != nil {
+		return fmt.Errorf("failed to store %s rollup of %s: %w", rollup.Granularity, rollup.ServiceName, err)
+	}
+
+	return nil
+}
+
+// Rollups queries a component's aggregates of one granularity starting
+// between from and to
+func (d *DynamoDBBackend) Rollups(ctx context.Context, serviceName, granularity string, from, to time.Time) ([]models.Rollup, error) {
+	paginator := dynamodb.NewQueryPaginator(d.client.client, &dynamodb.QueryInput{
+		TableName:              aws.String(d.tableName),
+		KeyConditionExpression: aws.String("serviceName = :key AND lastChecked BETWEEN :from AND :to"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":key":  &types.AttributeValueMemberS{Value: rollupKey(serviceName, granularity)},
+			":from": &types.AttributeValueMemberS{Value: from.UTC().Format(time.RFC3339)},
+			":to":   &types.AttributeValueMemberS{Value: to.UTC().Format(time.RFC3339)},
+		},
+	})
+
+	var rollups []models.Rollup
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query %s rollups of %s: %w", granularity, serviceName, err)
+		}
+		for _, item := range page.Items {
+			rollups = append(rollups, parseRollupItem(serviceName, granularity, item))
+		}
+	}
+
+	return rollups, nil
+}
+
+// LastRollup queries a component's latest aggregate of one granularity
+// with a one-item Query
+func (d *DynamoDBBackend) LastRollup(ctx context.Context, serviceName, granularity string) (models.Rollup, bool, error) {
+	result, err := d.client.client.Query(ctx, &dynamodb.QueryInput{
+		TableName:              aws.String(d.tableName),
+		KeyConditionExpression: aws.String("serviceName = :key"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":key": &types.AttributeValueMemberS{Value: rollupKey(serviceName, granularity)},
+		},
+		ScanIndexForward: aws.Bool(false),
+		Limit:            aws.Int32(1),
+	})
+	if err != nil {
+		return models.Rollup{}, false, fmt.Errorf("failed to query latest %s rollup of %s: %w", granularity, serviceName, err)
+	}
+	if len(result.Items) == 0 {
+		return models.Rollup{}, false, nil
+	}
+	return parseRollupItem(serviceName, granularity, result.Items[0]), true, nil
+}
+
+// parseRollupItem converts a DynamoDB item into a rollup
+func parseRollupItem(serviceName, granularity string, item map[string]types.AttributeValue) models.Rollup {
+	rollup := models.Rollup{
+		ServiceName: serviceName,
+		Granularity: granularity,
+	}
+
+	if start, ok := item["lastChecked"].(*types.AttributeValueMemberS); ok {
+		if timestamp, err := time.Parse(time.RFC3339, start.Value); err == nil {
+			rollup.Start = timestamp
+		}
+	}
+	if checks, ok := item["checks"].(*types.AttributeValueMemberN); ok {
+		rollup.Checks, _ = strconv.Atoi(checks.Value)
+	}
+	if operational, ok := item["operational"].(*types.AttributeValueMemberN); ok {
+		rollup.Operational, _ = strconv.Atoi(operational.Value)
+	}
+	if worst, ok := item["worstStatus"].(*types.AttributeValueMemberS); ok {
+		rollup.WorstStatus = worst.Value
+	}
+	for name, field := range map[string]*float64{
+		"minResponseTimeMs": &rollup.MinResponseTimeMs,
+		"avgResponseTimeMs": &rollup.AvgResponseTimeMs,
+		"maxResponseTimeMs": &rollup.MaxResponseTimeMs,
+		"p95ResponseTimeMs": &rollup.P95ResponseTimeMs,
+	} {
+		if value, ok := item[name].(*types.AttributeValueMemberN); ok {
+			*field, _ = strconv.ParseFloat(value.Value, 64)
+		}
+	}
+	if expiresAt, ok := item["expiresAt"].(*types.AttributeValueMemberN); ok {
+		if seconds, err := strconv.ParseInt(expiresAt.Value, 10, 64); err == nil {
+			rollup.ExpiresAt = time.Unix(seconds, 0).UTC()
+		}
+	}
+
+	return rollup
+}
+
+// AcquireLease takes or extends a lease item with a conditional write, which
+// fails while another holder's lease hasn't expired. Expiry is kept in
+// leaseExpiresAt (epoch milliseconds) rather than expiresAt, so DynamoDB TTL
+// never deletes a lease.
+func (d *DynamoDBBackend) AcquireLease(ctx context.Context, name, holder string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	item := leaseItemKey(name)
+	item["holder"] = &types.AttributeValueMemberS{Value: holder}
+	item["leaseExpiresAt"] = &types.AttributeValueMemberN{
+		Value: strconv.FormatInt(now.Add(ttl).UnixMilli(), 10),
+	}
+
+	_, err := d.client.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(d.tableName),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(serviceName) OR holder = :holder OR leaseExpiresAt <= :now"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":holder": &types.AttributeValueMemberS{Value: holder},
+			":now":    &types.AttributeValueMemberN{Value: strconv.FormatInt(now.UnixMilli(), 10)},
+		},
+	})
 
+	var conditionErr *types.ConditionalCheckFailedException
+	if errors.As(err, &conditionErr) {
+		return false, nil
+	}
 	if err != nil {
-		return fmt.Errorf("failed to store component %s: %w", component.Name, err)
+		return false, fmt.Errorf("failed to acquire lease %s: %w", name, err)
 	}
+	return true, nil
+}
+
+// ReleaseLease deletes a lease item if holder still has it
+func (d *DynamoDBBackend) ReleaseLease(ctx context.Context, name, holder string) error {
+	_, err := d.client.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName:           aws.String(d.tableName),
+		Key:                 leaseItemKey(name),
+		ConditionExpression: aws.String("holder = :holder"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":holder": &types.AttributeValueMemberS{Value: holder},
+		},
+	})
 
+	var conditionErr *types.ConditionalCheckFailedException
+	if err != nil && !errors.As(err, &conditionErr) {
+		return fmt.Errorf("failed to release lease %s: %w", name, err)
+	}
 	return nil
 }
+
+// leaseItemKey returns the primary key of a lease item
+func leaseItemKey(name string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"serviceName": &types.AttributeValueMemberS{Value: leaseKey},
+		"lastChecked": &types.AttributeValueMemberS{Value: name},
+	}
+}
@@ -0,0 +1,357 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/models"
+)
+
+// compactInterval bounds how often expired lines are rewritten out of the log
+const compactInterval = time.Hour
+
+// rollupEntry is how a rollup is written to the check log, distinguishing it
+// from plain check record lines
+type rollupEntry struct {
+	Rollup *models.Rollup `json:"rollup"`
+}
+
+// FileBackend stores check history and rollups in a single append-only file
+// of JSON lines. Every write is a single append, so one process can write while
+// others (e.g. the status page) read the same file; readers pick up new
+// lines incrementally before serving each query. Expired records are hidden
+// as soon as they lapse and dropped from the file by the writer's periodic
+// compaction. Appends and compaction hold a lock file next to the log, so
+// several writers (e.g. replicas sharing the log) don't lose each other's
+// lines when it's rewritten.
+type FileBackend struct {
+	path string
+	now  func() time.Time
+
+	mu      sync.Mutex
+	index   *recordIndex
+	rollups *rollupIndex
+	info    os.FileInfo
+	offset  int64
+
+	// expired counts lines still in the file that have been pruned from the indexes
+	expired     int
+	compactedAt time.Time
+}
+
+// NewFileBackend opens (or creates) the check log at path
+func NewFileBackend(path string) (*FileBackend, error) {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create storage directory: %w", err)
+		}
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open check log: %w", err)
+	}
+	f.Close()
+
+	b := &FileBackend{
+		path:    path,
+		now:     time.Now,
+		index:   newRecordIndex(),
+		rollups: newRollupIndex(),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.sync(); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// SetClock replaces the time source used to expire records
+func (b *FileBackend) SetClock(now func() time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.now = now
+}
+
+// Append writes check records to the end of the log, skipping records
+// already in it. Duplicates written concurrently by another process are
+// dropped when the log is read.
+func (b *FileBackend) Append(ctx context.Context, records []models.CheckRecord) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := b.sync(); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, record := range records {
+		if b.index.has(record.ServiceName, record.LastChecked) {
+			log.Printf("Skipping duplicate check of %s at %s", record.ServiceName, record.LastChecked.Format(time.RFC3339))
+			continue
+		}
+		if err := enc.Encode(record); err != nil {
+			return fmt.Errorf("failed to encode record for %s: %w", record.ServiceName, err)
+		}
+	}
+	if buf.Len() == 0 {
+		return nil
+	}
+
+	return b.writeLocked(ctx, buf.Bytes())
+}
+
+// PutRollups appends aggregates to the log; later lines replace earlier
+// rollups of the same period when the log is read
+func (b *FileBackend) PutRollups(ctx context.Context, rollups []models.Rollup) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for i := range rollups {
+		if err := enc.Encode(rollupEntry{Rollup: &rollups[i]}); err != nil {
+			return fmt.Errorf("failed to encode rollup for %s: %w", rollups[i].ServiceName, err)
+		}
+	}
+
+	return b.write(ctx, buf.Bytes())
+}
+
+// write appends encoded lines to the log, then compacts it if due
+func (b *FileBackend) write(ctx context.Context, data []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.writeLocked(ctx, data)
+}
+
+// writeLocked is write for callers already holding the lock
+func (b *FileBackend) writeLocked(ctx context.Context, data []byte) error {
+	// Other processes must not append while the log is rewritten, or their
+	// lines would be lost with the file being replaced
+	unlock, err := lockFile(ctx, b.path+".lock")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	f, err := os.OpenFile(b.path, os.O_APPEND|os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open check log: %w", err)
+	}
+
+	// Terminate a torn line left by a crashed writer so it doesn't swallow
+	// the first record of this batch
+	if info, err := f.Stat(); err == nil && info.Size() > 0 {
+		last := make([]byte, 1)
+		if _, err := f.ReadAt(last, info.Size()-1); err == nil && last[0] != '\n' {
+			data = append([]byte{'\n'}, data...)
+		}
+	}
+
+	// A single write keeps the batch contiguous for concurrent readers
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to append to check log: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to close check log: %w", err)
+	}
+
+	if err := b.sync(); err != nil {
+		return err
+	}
+
+	if b.expired > 0 && b.now().Sub(b.compactedAt) >= compactInterval {
+		return b.compact()
+	}
+	return nil
+}
+
+// compact rewrites the log with only the live records and atomically swaps
+// it into place. Readers notice the new file and reload it on their next sync.
+func (b *FileBackend) compact() error {
+	tmp, err := os.CreateTemp(filepath.Dir(b.path), filepath.Base(b.path)+".compact-*")
+	if err != nil {
+		return fmt.Errorf("failed to create compacted log: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	enc := json.NewEncoder(tmp)
+	for _, record := range b.index.all() {
+		if err := enc.Encode(record); err != nil {
+			tmp.Close()
+			return fmt.Errorf("failed to write compacted log: %w", err)
+		}
+	}
+	for _, rollup := range b.rollups.all() {
+		if err := enc.Encode(rollupEntry{Rollup: &rollup}); err != nil {
+			tmp.Close()
+			return fmt.Errorf("failed to write compacted log: %w", err)
+		}
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync compacted log: %w", err)
+	}
+	info, err := tmp.Stat()
+	if err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to stat compacted log: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close compacted log: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), b.path); err != nil {
+		return fmt.Errorf("failed to replace check log: %w", err)
+	}
+
+	b.info = info
+	b.offset = info.Size()
+	b.expired = 0
+	b.compactedAt = b.now()
+	return nil
+}
+
+// Latest returns the most recent record of every component in the log
+func (b *FileBackend) Latest(ctx context.Context) ([]models.CheckRecord, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := b.sync(); err != nil {
+		return nil, err
+	}
+	return b.index.latest(), nil
+}
+
+// Last returns the most recent record of a single component in the log
+func (b *FileBackend) Last(ctx context.Context, serviceName string) (models.CheckRecord, bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := b.sync(); err != nil {
+		return models.CheckRecord{}, false, err
+	}
+	record, ok := b.index.last(serviceName)
+	return record, ok, nil
+}
+
+// Range returns the records of a single component between from and to
+func (b *FileBackend) Range(ctx context.Context, serviceName string, from, to time.Time) ([]models.CheckRecord, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := b.sync(); err != nil {
+		return nil, err
+	}
+	return b.index.between(serviceName, from, to), nil
+}
+
+// Rollups returns a component's aggregates starting between from and to
+func (b *FileBackend) Rollups(ctx context.Context, serviceName, granularity string, from, to time.Time) ([]models.Rollup, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := b.sync(); err != nil {
+		return nil, err
+	}
+	return b.rollups.between(serviceName, granularity, from, to), nil
+}
+
+// LastRollup returns a component's latest aggregate of one granularity
+func (b *FileBackend) LastRollup(ctx context.Context, serviceName, granularity string) (models.Rollup, bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := b.sync(); err != nil {
+		return models.Rollup{}, false, err
+	}
+	rollup, ok := b.rollups.last(serviceName, granularity)
+	return rollup, ok, nil
+}
+
+// sync loads any lines appended to the log since the last read. If the file
+// was replaced or truncated, the index is rebuilt from the start.
+func (b *FileBackend) sync() error {
+	f, err := os.Open(b.path)
+	if err != nil {
+		return fmt.Errorf("failed to open check log: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat check log: %w", err)
+	}
+
+	if b.info == nil || !os.SameFile(b.info, info) || info.Size() < b.offset {
+		b.index = newRecordIndex()
+		b.rollups = newRollupIndex()
+		b.offset = 0
+		b.expired = 0
+	}
+	b.info = info
+
+	if info.Size() == b.offset {
+		b.prune()
+		return nil
+	}
+
+	if _, err := f.Seek(b.offset, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to seek check log: %w", err)
+	}
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return fmt.Errorf("failed to read check log: %w", err)
+	}
+
+	// Only consume complete lines; a trailing partial line is still being written
+	end := bytes.LastIndexByte(data, '\n')
+	if end < 0 {
+		return nil
+	}
+
+	for _, line := range bytes.Split(data[:end], []byte("\n")) {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		// A crash mid-write can leave a torn line behind; skip it rather
+		// than refusing to serve the rest of the history
+		var entry struct {
+			models.CheckRecord
+			rollupEntry
+		}
+		if err := json.Unmarshal(line, &entry); err != nil {
+			log.Printf("Skipping corrupt check log entry in %s: %v", b.path, err)
+			continue
+		}
+
+		if entry.Rollup != nil {
+			b.rollups.upsert(*entry.Rollup)
+		} else {
+			b.index.insert(entry.CheckRecord)
+		}
+	}
+	b.offset += int64(end + 1)
+	b.prune()
+
+	return nil
+}
+
+// prune hides expired records and rollups, counting them towards compaction
+func (b *FileBackend) prune() {
+	now := b.now()
+	b.expired += b.index.prune(now) + b.rollups.prune(now)
+}
@@ -0,0 +1,133 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/models"
+)
+
+func TestFileBackend(t *testing.T) {
+	backend, err := NewFileBackend(filepath.Join(t.TempDir(), "data", "checks.jsonl"))
+	if err != nil {
+		t.Fatalf("NewFileBackend() error = %v", err)
+	}
+	testBackend(t, backend)
+	testBackendRollups(t, backend)
+
+	// Records and rollups share the log and both survive a reopen
+	reopened, err := NewFileBackend(filepath.Join(filepath.Dir(backend.path), "checks.jsonl"))
+	if err != nil {
+		t.Fatalf("NewFileBackend() error = %v", err)
+	}
+	testBackendRollups(t, reopened)
+	if latest, _ := reopened.Latest(context.Background()); len(latest) != 2 {
+		t.Errorf("reopened log has %d components, want 2", len(latest))
+	}
+}
+
+func TestFileBackendSharedLog(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "checks.jsonl")
+
+	writer, err := NewFileBackend(path)
+	if err != nil {
+		t.Fatalf("NewFileBackend() error = %v", err)
+	}
+	reader, err := NewFileBackend(path)
+	if err != nil {
+		t.Fatalf("NewFileBackend() error = %v", err)
+	}
+
+	if err := writer.Append(ctx, []models.CheckRecord{check("api", "OPERATIONAL", time.Minute)}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+
+	// A torn line from a crashed writer must not hide later records
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString("{\"serviceName\":\"api\",\"sta")
+	f.Close()
+
+	if err := writer.Append(ctx, []models.CheckRecord{check("api", "DOWN", 0)}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+
+	records, err := reader.Range(ctx, "api", testNow.Add(-time.Hour), testNow)
+	if err != nil {
+		t.Fatalf("Range() error = %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("reader saw %d records, want 2", len(records))
+	}
+	if records[1].Status != "DOWN" {
+		t.Errorf("latest status = %s, want DOWN", records[1].Status)
+	}
+}
+
+func TestFileBackendCompaction(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "checks.jsonl")
+
+	now := testNow
+	backend, err := NewFileBackend(path)
+	if err != nil {
+		t.Fatalf("NewFileBackend() error = %v", err)
+	}
+	backend.SetClock(func() time.Time { return now })
+
+	expiring := check("api", "DOWN", time.Minute)
+	expiring.ExpiresAt = testNow.Add(time.Minute)
+	if err := backend.Append(ctx, []models.CheckRecord{expiring, check("api", "OPERATIONAL", 0)}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+
+	// Once the first record lapses, the next write compacts it out of the file
+	now = testNow.Add(2 * time.Hour)
+	if err := backend.Append(ctx, []models.CheckRecord{check("api", "OPERATIONAL", -time.Hour)}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(string(data), "\n"); lines != 2 {
+		t.Errorf("compacted log has %d lines, want 2", lines)
+	}
+	if strings.Contains(string(data), "DOWN") {
+		t.Error("expired record survived compaction")
+	}
+}
+
+func TestFileBackendWaitsForLogLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checks.jsonl")
+	backend, err := NewFileBackend(path)
+	if err != nil {
+		t.Fatalf("NewFileBackend() error = %v", err)
+	}
+
+	// Another process holds the lock while it compacts the log
+	if err := os.WriteFile(path+".lock", nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := backend.Append(ctx, []models.CheckRecord{check("api", "OPERATIONAL", 0)}); err == nil {
+		t.Fatal("Append() succeeded while the log was locked")
+	}
+
+	os.Remove(path + ".lock")
+	if err := backend.Append(context.Background(), []models.CheckRecord{check("api", "OPERATIONAL", 0)}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	if _, ok, _ := backend.Last(context.Background(), "api"); !ok {
+		t.Error("record appended after the lock was released is missing")
+	}
+}
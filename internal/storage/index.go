@@ -0,0 +1,227 @@
+package storage
+
+import (
+	"sort"
+	"time"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/models"
+)
+
+// recordIndex keeps check records in memory, grouped by component and ordered
+// by check time. It is not safe for concurrent use; callers hold their own lock.
+type recordIndex struct {
+	components map[string][]models.CheckRecord
+}
+
+// newRecordIndex creates an empty record index
+func newRecordIndex() *recordIndex {
+	return &recordIndex{
+		components: make(map[string][]models.CheckRecord),
+	}
+}
+
+// insert adds a record, keeping the component's records ordered by time. A
+// record checked at the same time as one already indexed is a duplicate
+// write and is dropped; insert reports whether the record was added.
+func (idx *recordIndex) insert(record models.CheckRecord) bool {
+	records := idx.components[record.ServiceName]
+
+	// Checks almost always arrive in order, so appending is the common case
+	if n := len(records); n == 0 || record.LastChecked.After(records[n-1].LastChecked) {
+		idx.components[record.ServiceName] = append(records, record)
+		return true
+	}
+
+	i := sort.Search(len(records), func(i int) bool {
+		return !records[i].LastChecked.Before(record.LastChecked)
+	})
+	if i < len(records) && records[i].LastChecked.Equal(record.LastChecked) {
+		return false
+	}
+	records = append(records, models.CheckRecord{})
+	copy(records[i+1:], records[i:])
+	records[i] = record
+	idx.components[record.ServiceName] = records
+	return true
+}
+
+// has reports whether a component has a record checked at the given time
+func (idx *recordIndex) has(serviceName string, checkedAt time.Time) bool {
+	records := idx.components[serviceName]
+	i := sort.Search(len(records), func(i int) bool {
+		return !records[i].LastChecked.Before(checkedAt)
+	})
+	return i < len(records) && records[i].LastChecked.Equal(checkedAt)
+}
+
+// latest returns the newest record of every component, ordered by name
+func (idx *recordIndex) latest() []models.CheckRecord {
+	records := make([]models.CheckRecord, 0, len(idx.components))
+	for _, items := range idx.components {
+		if len(items) > 0 {
+			records = append(records, items[len(items)-1])
+		}
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].ServiceName < records[j].ServiceName
+	})
+	return records
+}
+
+// last returns the newest record of a component
+func (idx *recordIndex) last(serviceName string) (models.CheckRecord, bool) {
+	items := idx.components[serviceName]
+	if len(items) == 0 {
+		return models.CheckRecord{}, false
+	}
+	return items[len(items)-1], true
+}
+
+// between returns a copy of a component's records checked within [from, to]
+func (idx *recordIndex) between(serviceName string, from, to time.Time) []models.CheckRecord {
+	items := idx.components[serviceName]
+
+	start := sort.Search(len(items), func(i int) bool {
+		return !items[i].LastChecked.Before(from)
+	})
+	end := sort.Search(len(items), func(i int) bool {
+		return items[i].LastChecked.After(to)
+	})
+	if start >= end {
+		return nil
+	}
+
+	records := make([]models.CheckRecord, end-start)
+	copy(records, items[start:end])
+	return records
+}
+
+// prune drops records whose expiry has passed and returns how many were
+// removed. Records expire in check order, so each component is trimmed from
+// the oldest end until the first record that is still live.
+func (idx *recordIndex) prune(now time.Time) int {
+	removed := 0
+	for name, items := range idx.components {
+		n := 0
+		for n < len(items) && !items[n].ExpiresAt.IsZero() && items[n].ExpiresAt.Before(now) {
+			n++
+		}
+		if n == 0 {
+			continue
+		}
+
+		removed += n
+		if n == len(items) {
+			delete(idx.components, name)
+			continue
+		}
+		idx.components[name] = append([]models.CheckRecord(nil), items[n:]...)
+	}
+	return removed
+}
+
+// all returns every record in the index, grouped by component
+func (idx *recordIndex) all() []models.CheckRecord {
+	var records []models.CheckRecord
+	for _, items := range idx.components {
+		records = append(records, items...)
+	}
+	return records
+}
+
+// rollupIndex keeps aggregates in memory, grouped by component and
+// granularity and ordered by period start. Like recordIndex it relies on
+// the caller for locking.
+type rollupIndex struct {
+	series map[string][]models.Rollup
+}
+
+// newRollupIndex creates an empty rollup index
+func newRollupIndex() *rollupIndex {
+	return &rollupIndex{
+		series: make(map[string][]models.Rollup),
+	}
+}
+
+// rollupKey identifies the series a rollup belongs to
+func rollupKey(serviceName, granularity string) string {
+	return serviceName + "#" + granularity
+}
+
+// upsert adds a rollup, replacing one with the same start if present
+func (idx *rollupIndex) upsert(rollup models.Rollup) {
+	key := rollupKey(rollup.ServiceName, rollup.Granularity)
+	items := idx.series[key]
+
+	i := sort.Search(len(items), func(i int) bool {
+		return !items[i].Start.Before(rollup.Start)
+	})
+	if i < len(items) && items[i].Start.Equal(rollup.Start) {
+		items[i] = rollup
+		return
+	}
+
+	items = append(items, models.Rollup{})
+	copy(items[i+1:], items[i:])
+	items[i] = rollup
+	idx.series[key] = items
+}
+
+// between returns a copy of a series' rollups starting within [from, to]
+func (idx *rollupIndex) between(serviceName, granularity string, from, to time.Time) []models.Rollup {
+	items := idx.series[rollupKey(serviceName, granularity)]
+
+	start := sort.Search(len(items), func(i int) bool {
+		return !items[i].Start.Before(from)
+	})
+	end := sort.Search(len(items), func(i int) bool {
+		return items[i].Start.After(to)
+	})
+	if start >= end {
+		return nil
+	}
+
+	rollups := make([]models.Rollup, end-start)
+	copy(rollups, items[start:end])
+	return rollups
+}
+
+// last returns a series' rollup with the latest start
+func (idx *rollupIndex) last(serviceName, granularity string) (models.Rollup, bool) {
+	items := idx.series[rollupKey(serviceName, granularity)]
+	if len(items) == 0 {
+		return models.Rollup{}, false
+	}
+	return items[len(items)-1], true
+}
+
+// prune drops expired rollups and returns how many were removed
+func (idx *rollupIndex) prune(now time.Time) int {
+	removed := 0
+	for key, items := range idx.series {
+		n := 0
+		for n < len(items) && !items[n].ExpiresAt.IsZero() && items[n].ExpiresAt.Before(now) {
+			n++
+		}
+		if n == 0 {
+			continue
+		}
+
+		removed += n
+		if n == len(items) {
+			delete(idx.series, key)
+			continue
+		}
+		idx.series[key] = append([]models.Rollup(nil), items[n:]...)
+	}
+	return removed
+}
+
+// all returns every rollup in the index
+func (idx *rollupIndex) all() []models.Rollup {
+	var rollups []models.Rollup
+	for _, items := range idx.series {
+		rollups = append(rollups, items...)
+	}
+	return rollups
+}
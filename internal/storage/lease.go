@@ -0,0 +1,173 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Leases is implemented by backends that can hold named leases, used to
+// elect a single leader among replicas sharing the backend. Expiry is
+// judged by each replica's clock, so clocks should be kept in sync.
+type Leases interface {
+	// AcquireLease takes the named lease for holder, or extends it if holder
+	// already has it, until ttl from now. It reports false if another
+	// holder's lease hasn't expired yet.
+	AcquireLease(ctx context.Context, name, holder string, ttl time.Duration) (bool, error)
+
+	// ReleaseLease ends holder's lease early so another replica can take it.
+	// Releasing a lease held by someone else does nothing.
+	ReleaseLease(ctx context.Context, name, holder string) error
+}
+
+// lease is the current holder of a named lease
+type lease struct {
+	Holder    string    `json:"holder"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// available reports whether holder may take the lease at now
+func (l lease) available(holder string, now time.Time) bool {
+	return l.Holder == "" || l.Holder == holder || !now.Before(l.ExpiresAt)
+}
+
+// AcquireLease takes or extends a lease held in process memory
+func (m *MemoryBackend) AcquireLease(ctx context.Context, name, holder string, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	if !m.leases[name].available(holder, now) {
+		return false, nil
+	}
+	m.leases[name] = lease{Holder: holder, ExpiresAt: now.Add(ttl)}
+	return true, nil
+}
+
+// ReleaseLease ends a lease held in process memory
+func (m *MemoryBackend) ReleaseLease(ctx context.Context, name, holder string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.leases[name].Holder == holder {
+		delete(m.leases, name)
+	}
+	return nil
+}
+
+// The lease file and the check log are each guarded by a lock file; one left
+// behind by a crashed process is removed once it's older than staleLockAge
+const (
+	lockRetryInterval = 10 * time.Millisecond
+	lockTimeout       = 2 * time.Second
+	staleLockAge      = 10 * time.Second
+)
+
+// AcquireLease takes or extends a lease kept in a file next to the check
+// log, shared by every process using the log
+func (b *FileBackend) AcquireLease(ctx context.Context, name, holder string, ttl time.Duration) (bool, error) {
+	acquired := false
+	err := b.updateLeases(ctx, func(leases map[string]lease) bool {
+		now := b.now()
+		if !leases[name].available(holder, now) {
+			return false
+		}
+		leases[name] = lease{Holder: holder, ExpiresAt: now.Add(ttl)}
+		acquired = true
+		return true
+	})
+	return acquired, err
+}
+
+// ReleaseLease ends a lease kept in the lease file
+func (b *FileBackend) ReleaseLease(ctx context.Context, name, holder string) error {
+	return b.updateLeases(ctx, func(leases map[string]lease) bool {
+		if leases[name].Holder != holder {
+			return false
+		}
+		delete(leases, name)
+		return true
+	})
+}
+
+// updateLeases reads the lease file under its lock file and, if update
+// reports a change, atomically replaces it
+func (b *FileBackend) updateLeases(ctx context.Context, update func(map[string]lease) bool) error {
+	path := b.path + ".leases"
+	unlock, err := lockFile(ctx, path+".lock")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	leases := make(map[string]lease)
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read lease file: %w", err)
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &leases); err != nil {
+			return fmt.Errorf("invalid lease file %s: %w", path, err)
+		}
+	}
+
+	if !update(leases) {
+		return nil
+	}
+
+	data, err = json.Marshal(leases)
+	if err != nil {
+		return fmt.Errorf("failed to encode leases: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create lease file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write lease file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write lease file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace lease file: %w", err)
+	}
+	return nil
+}
+
+// lockFile creates path exclusively, waiting while another process holds
+// it, and returns a function removing it again. It's used by the lease file
+// and the check log alike.
+func lockFile(ctx context.Context, path string) (func(), error) {
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err == nil {
+			f.Close()
+			return func() { os.Remove(path) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("failed to create lock file: %w", err)
+		}
+
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > staleLockAge {
+			os.Remove(path)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for lock file %s", path)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(lockRetryInterval):
+		}
+	}
+}
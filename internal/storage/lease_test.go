@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLeases(t *testing.T) {
+	memory := NewMemoryBackend()
+	file, err := NewFileBackend(filepath.Join(t.TempDir(), "checks.jsonl"))
+	if err != nil {
+		t.Fatalf("NewFileBackend() error = %v", err)
+	}
+
+	backends := []struct {
+		name     string
+		leases   Leases
+		setClock func(func() time.Time)
+	}{
+		{"memory", memory, memory.SetClock},
+		{"file", file, file.SetClock},
+	}
+
+	for _, backend := range backends {
+		t.Run(backend.name, func(t *testing.T) {
+			ctx := context.Background()
+			now := testNow
+			backend.setClock(func() time.Time { return now })
+
+			steps := []struct {
+				name    string
+				advance time.Duration
+				holder  string
+				release bool
+				want    bool
+			}{
+				{"free lease is taken", 0, "a", false, true},
+				{"held lease is refused", 5 * time.Second, "b", false, false},
+				{"holder renews", 0, "a", false, true},
+				{"renewal extends the lease", 10 * time.Second, "b", false, false},
+				{"expired lease is taken over", 6 * time.Second, "b", false, true},
+				{"releasing someone else's lease does nothing", 0, "a", true, false},
+				{"released lease is free", 0, "b", true, true},
+				{"released lease is taken", 0, "a", false, true},
+			}
+
+			for _, step := range steps {
+				now = now.Add(step.advance)
+				if step.release {
+					if err := backend.leases.ReleaseLease(ctx, "scheduler", step.holder); err != nil {
+						t.Fatalf("%s: ReleaseLease() error = %v", step.name, err)
+					}
+					// A release is checked by whether a third holder can take the lease
+					got, err := backend.leases.AcquireLease(ctx, "scheduler", "c", 15*time.Second)
+					if err != nil {
+						t.Fatalf("%s: AcquireLease() error = %v", step.name, err)
+					}
+					if got != step.want {
+						t.Errorf("%s: lease free = %v, want %v", step.name, got, step.want)
+					}
+					if got {
+						backend.leases.ReleaseLease(ctx, "scheduler", "c")
+					}
+					continue
+				}
+
+				got, err := backend.leases.AcquireLease(ctx, "scheduler", step.holder, 15*time.Second)
+				if err != nil {
+					t.Fatalf("%s: AcquireLease() error = %v", step.name, err)
+				}
+				if got != step.want {
+					t.Errorf("%s: AcquireLease(%s) = %v, want %v", step.name, step.holder, got, step.want)
+				}
+			}
+		})
+	}
+}
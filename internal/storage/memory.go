@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/models"
+)
+
+// MemoryBackend keeps check history in process memory. Nothing is persisted,
+// which makes it suitable for tests and short-lived local runs.
+type MemoryBackend struct {
+	mu      sync.RWMutex
+	index   *recordIndex
+	rollups *rollupIndex
+	leases  map[string]lease
+	now     func() time.Time
+}
+
+// NewMemoryBackend creates an empty in-memory storage backend
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{
+		index:   newRecordIndex(),
+		rollups: newRollupIndex(),
+		leases:  make(map[string]lease),
+		now:     time.Now,
+	}
+}
+
+// SetClock replaces the time source used to expire records
+func (m *MemoryBackend) SetClock(now func() time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.now = now
+}
+
+// Append stores check records in memory and drops expired ones
+func (m *MemoryBackend) Append(ctx context.Context, records []models.CheckRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, record := range records {
+		m.index.insert(record)
+	}
+	m.index.prune(m.now())
+	return nil
+}
+
+// Latest returns the most recent record of every component
+func (m *MemoryBackend) Latest(ctx context.Context) ([]models.CheckRecord, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.index.latest(), nil
+}
+
+// Last returns the most recent record of a single component
+func (m *MemoryBackend) Last(ctx context.Context, serviceName string) (models.CheckRecord, bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	record, ok := m.index.last(serviceName)
+	return record, ok, nil
+}
+
+// Range returns the records of a single component between from and to
+func (m *MemoryBackend) Range(ctx context.Context, serviceName string, from, to time.Time) ([]models.CheckRecord, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.index.between(serviceName, from, to), nil
+}
+
+// PutRollups stores aggregates in memory and drops expired ones
+func (m *MemoryBackend) PutRollups(ctx context.Context, rollups []models.Rollup) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, rollup := range rollups {
+		m.rollups.upsert(rollup)
+	}
+	m.rollups.prune(m.now())
+	return nil
+}
+
+// Rollups returns a component's aggregates starting between from and to
+func (m *MemoryBackend) Rollups(ctx context.Context, serviceName, granularity string, from, to time.Time) ([]models.Rollup, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.rollups.between(serviceName, granularity, from, to), nil
+}
+
+// LastRollup returns a component's latest aggregate of one granularity
+func (m *MemoryBackend) LastRollup(ctx context.Context, serviceName, granularity string) (models.Rollup, bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	rollup, ok := m.rollups.last(serviceName, granularity)
+	return rollup, ok, nil
+}
@@ -0,0 +1,183 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/models"
+)
+
+var testNow = time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+func check(name, status string, age time.Duration) models.CheckRecord {
+	return models.CheckRecord{
+		ServiceName: name,
+		Status:      status,
+		LastChecked: testNow.Add(-age),
+	}
+}
+
+// testBackend exercises the behaviour every Backend implementation shares
+func testBackend(t *testing.T, backend Backend) {
+	t.Helper()
+	ctx := context.Background()
+
+	// Deliberately out of order to cover sorted insertion
+	err := backend.Append(ctx, []models.CheckRecord{
+		check("api", "OPERATIONAL", time.Minute),
+		check("database", "DOWN", time.Minute),
+		check("api", "DOWN", 3*time.Minute),
+		check("api", "DEGRADED", 2*time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+
+	// Writing a check again for the same time, in or out of order, keeps
+	// the stored one
+	err = backend.Append(ctx, []models.CheckRecord{
+		check("api", "DOWN", time.Minute),
+		check("api", "OPERATIONAL", 2*time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("Append() of duplicates error = %v", err)
+	}
+
+	latest, err := backend.Latest(ctx)
+	if err != nil {
+		t.Fatalf("Latest() error = %v", err)
+	}
+	if len(latest) != 2 {
+		t.Fatalf("Latest() returned %d records, want 2", len(latest))
+	}
+	if latest[0].ServiceName != "api" || latest[0].Status != "OPERATIONAL" {
+		t.Errorf("Latest()[0] = %+v, want api OPERATIONAL", latest[0])
+	}
+	if latest[1].ServiceName != "database" || latest[1].Status != "DOWN" {
+		t.Errorf("Latest()[1] = %+v, want database DOWN", latest[1])
+	}
+
+	if last, ok, err := backend.Last(ctx, "api"); err != nil || !ok || last.Status != "OPERATIONAL" {
+		t.Errorf("Last(api) = %+v, %v, %v, want the OPERATIONAL check", last, ok, err)
+	}
+	if _, ok, err := backend.Last(ctx, "cache"); err != nil || ok {
+		t.Errorf("Last(cache) = %v, %v, want no record", ok, err)
+	}
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		want     []string
+	}{
+		{"whole history oldest first", testNow.Add(-time.Hour), testNow, []string{"DOWN", "DEGRADED", "OPERATIONAL"}},
+		{"bounds are inclusive", testNow.Add(-3 * time.Minute), testNow.Add(-2 * time.Minute), []string{"DOWN", "DEGRADED"}},
+		{"empty window", testNow.Add(-time.Hour), testNow.Add(-30 * time.Minute), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, err := backend.Range(ctx, "api", tt.from, tt.to)
+			if err != nil {
+				t.Fatalf("Range() error = %v", err)
+			}
+			if len(records) != len(tt.want) {
+				t.Fatalf("Range() returned %d records, want %d", len(records), len(tt.want))
+			}
+			for i, record := range records {
+				if record.Status != tt.want[i] {
+					t.Errorf("record %d status = %s, want %s", i, record.Status, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+// testBackendRollups exercises rollup storage shared by every Backend
+func testBackendRollups(t *testing.T, backend Backend) {
+	t.Helper()
+	ctx := context.Background()
+
+	hour := testNow.Truncate(time.Hour)
+	err := backend.PutRollups(ctx, []models.Rollup{
+		{ServiceName: "api", Granularity: models.GranularityHour, Start: hour.Add(-time.Hour), Checks: 60},
+		{ServiceName: "api", Granularity: models.GranularityHour, Start: hour.Add(-2 * time.Hour), Checks: 60},
+		{ServiceName: "api", Granularity: models.GranularityDay, Start: hour.Truncate(24 * time.Hour), Checks: 1440},
+	})
+	if err != nil {
+		t.Fatalf("PutRollups() error = %v", err)
+	}
+
+	// Rewriting a period replaces it instead of adding a second rollup
+	err = backend.PutRollups(ctx, []models.Rollup{
+		{ServiceName: "api", Granularity: models.GranularityHour, Start: hour.Add(-time.Hour), Checks: 59},
+	})
+	if err != nil {
+		t.Fatalf("PutRollups() error = %v", err)
+	}
+
+	rollups, err := backend.Rollups(ctx, "api", models.GranularityHour, hour.Add(-24*time.Hour), hour)
+	if err != nil {
+		t.Fatalf("Rollups() error = %v", err)
+	}
+	if len(rollups) != 2 {
+		t.Fatalf("got %d hourly rollups, want 2", len(rollups))
+	}
+	if !rollups[0].Start.Equal(hour.Add(-2*time.Hour)) || rollups[1].Checks != 59 {
+		t.Errorf("hourly rollups = %+v, want oldest first with the rewrite applied", rollups)
+	}
+
+	if last, ok, err := backend.LastRollup(ctx, "api", models.GranularityHour); err != nil || !ok || !last.Start.Equal(hour.Add(-time.Hour)) {
+		t.Errorf("LastRollup() = %+v, %v, %v, want the rollup of the previous hour", last, ok, err)
+	}
+	if _, ok, err := backend.LastRollup(ctx, "cache", models.GranularityHour); err != nil || ok {
+		t.Errorf("LastRollup(cache) = %v, %v, want no rollup", ok, err)
+	}
+}
+
+func TestMemoryBackend(t *testing.T) {
+	testBackend(t, NewMemoryBackend())
+	testBackendRollups(t, NewMemoryBackend())
+}
+
+func TestMemoryBackendUnknownComponent(t *testing.T) {
+	records, err := NewMemoryBackend().Range(context.Background(), "missing", testNow.Add(-time.Hour), testNow)
+	if err != nil {
+		t.Fatalf("Range() error = %v", err)
+	}
+	if len(records) != 0 {
+		t.Errorf("Range() returned %d records, want 0", len(records))
+	}
+}
+
+func TestMemoryBackendPrunesExpired(t *testing.T) {
+	ctx := context.Background()
+	backend := NewMemoryBackend()
+	backend.SetClock(func() time.Time { return testNow })
+
+	expiring := func(name string, age, ttl time.Duration) models.CheckRecord {
+		record := check(name, "OPERATIONAL", age)
+		record.ExpiresAt = record.LastChecked.Add(ttl)
+		return record
+	}
+
+	err := backend.Append(ctx, []models.CheckRecord{
+		expiring("api", 3*time.Hour, time.Hour),
+		expiring("api", 2*time.Hour, 3*time.Hour),
+		expiring("old", 5*time.Hour, time.Hour),
+		check("forever", "OPERATIONAL", 100*24*time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+
+	records, _ := backend.Range(ctx, "api", testNow.Add(-24*time.Hour), testNow)
+	if len(records) != 1 || !records[0].LastChecked.Equal(testNow.Add(-2*time.Hour)) {
+		t.Errorf("api records = %+v, want only the unexpired check", records)
+	}
+
+	latest, _ := backend.Latest(ctx)
+	if len(latest) != 2 || latest[0].ServiceName != "api" || latest[1].ServiceName != "forever" {
+		t.Errorf("Latest() = %+v, want api and forever", latest)
+	}
+}
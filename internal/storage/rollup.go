@@ -0,0 +1,183 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/models"
+)
+
+// Rollups are kept long enough to cover the status page's longest views:
+// hourly ones back the 7d/30d uptime stats, daily ones the 90-day history.
+var rollupRetention = map[string]time.Duration{
+	models.GranularityHour: 31 * 24 * time.Hour,
+	models.GranularityDay:  91 * 24 * time.Hour,
+}
+
+// RollupPeriod returns the length of a rollup granularity
+func RollupPeriod(granularity string) time.Duration {
+	if granularity == models.GranularityDay {
+		return 24 * time.Hour
+	}
+	return time.Hour
+}
+
+// Summarize builds the rollup of one component's checks over the period
+// starting at start
+func Summarize(serviceName, granularity string, start time.Time, records []models.CheckRecord) models.Rollup {
+	rollup := models.Rollup{
+		ServiceName: serviceName,
+		Granularity: granularity,
+		Start:       start.UTC(),
+		Checks:      len(records),
+		WorstStatus: models.StatusOperational,
+	}
+	if len(records) == 0 {
+		return rollup
+	}
+
+	latencies := make([]float64, 0, len(records))
+	var total float64
+	for _, record := range records {
+		if record.Status == models.StatusOperational {
+			rollup.Operational++
+		}
+		rollup.WorstStatus = models.WorseStatus(rollup.WorstStatus, record.Status)
+		latencies = append(latencies, record.InternalResponseTimeMs)
+		total += record.InternalResponseTimeMs
+	}
+
+	sort.Float64s(latencies)
+	rollup.MinResponseTimeMs = latencies[0]
+	rollup.MaxResponseTimeMs = latencies[len(latencies)-1]
+	rollup.AvgResponseTimeMs = total / float64(len(latencies))
+
+	// Nearest-rank percentile
+	rank := int(math.Ceil(0.95*float64(len(latencies)))) - 1
+	rollup.P95ResponseTimeMs = latencies[rank]
+
+	return rollup
+}
+
+// rollupCompleted summarises, for every component, each period that closed
+// since its latest stored rollup, up to the one before the period containing
+// checkedAt. It runs on the write path so aggregates are ready as soon as a
+// period closes. Periods whose rollups failed to store are retried by the
+// next write of any process, since they follow the latest stored rollup.
+// previous holds each component's check before this one, which bounds the
+// periods of a component without rollups yet. Periods without checks, such
+// as those skipped by intervals longer than an hour or by a gap in
+// monitoring, get an empty rollup.
+func (s *Service) rollupCompleted(ctx context.Context, serviceNames []string, previous map[string]models.CheckRecord, checkedAt time.Time) error {
+	var rollups []models.Rollup
+	completed := make(map[string]time.Time)
+
+	for _, granularity := range []string{models.GranularityHour, models.GranularityDay} {
+		period := RollupPeriod(granularity)
+		last := checkedAt.Truncate(period).Add(-period)
+
+		for _, name := range serviceNames {
+			key := name + "#" + granularity
+
+			// This process remembers the periods it rolled up; otherwise
+			// the stored rollups tell where to continue
+			s.mu.Lock()
+			rolledUp, known := s.rolledUp[key]
+			s.mu.Unlock()
+			if !known {
+				latest, ok, err := s.backend.LastRollup(ctx, name, granularity)
+				if err != nil {
+					return fmt.Errorf("failed to read latest %s rollup of %s: %w", granularity, name, err)
+				}
+				rolledUp, known = latest.Start, ok
+			}
+			if known && !rolledUp.Before(last) {
+				completed[key] = rolledUp
+				continue
+			}
+
+			// A component without rollups starts from its previous check's
+			// period; its first check has nothing to roll up
+			var first time.Time
+			if known {
+				first = rolledUp.Add(period)
+			} else if check, ok := previous[name]; ok {
+				first = check.LastChecked.Truncate(period)
+			} else {
+				completed[key] = last
+				continue
+			}
+			// Rollups older than their retention would expire right away
+			if oldest := last.Add(-rollupRetention[granularity]).Truncate(period); first.Before(oldest) {
+				first = oldest
+			}
+
+			pending, err := s.summarizePeriods(ctx, name, granularity, first, last)
+			if err != nil {
+				return err
+			}
+			rollups = append(rollups, pending...)
+			completed[key] = last
+		}
+	}
+
+	if len(rollups) > 0 {
+		if err := s.backend.PutRollups(ctx, rollups); err != nil {
+			return err
+		}
+	}
+
+	// Only remember periods once their rollups are safely stored
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, start := range completed {
+		s.rolledUp[key] = start
+	}
+	return nil
+}
+
+// summarizePeriods builds the rollups of a component's periods starting
+// between first and last that have no rollup yet
+func (s *Service) summarizePeriods(ctx context.Context, name, granularity string, first, last time.Time) ([]models.Rollup, error) {
+	period := RollupPeriod(granularity)
+
+	existing, err := s.backend.Rollups(ctx, name, granularity, first, last)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s rollups of %s: %w", granularity, name, err)
+	}
+	done := make(map[int64]bool, len(existing))
+	for _, rollup := range existing {
+		done[rollup.Start.Unix()] = true
+	}
+	if len(done) == int(last.Sub(first)/period)+1 {
+		return nil, nil // every period is rolled up already
+	}
+
+	records, err := s.backend.Range(ctx, name, first, last.Add(period-time.Nanosecond))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checks of %s for rollup: %w", name, err)
+	}
+
+	// Records come oldest first, so each period's checks are contiguous.
+	// Periods without checks get an empty rollup, so readers can tell a
+	// period without checks from one that wasn't rolled up.
+	var rollups []models.Rollup
+	i := 0
+	for start := first; !start.After(last); start = start.Add(period) {
+		j := i
+		for j < len(records) && records[j].LastChecked.Truncate(period).Equal(start) {
+			j++
+		}
+		if !done[start.Unix()] {
+			rollup := Summarize(name, granularity, start, records[i:j])
+			rollup.ExpiresAt = start.Add(rollupRetention[granularity])
+			rollups = append(rollups, rollup)
+		}
+		i = j
+	}
+
+	return rollups, nil
+}
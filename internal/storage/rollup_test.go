@@ -0,0 +1,237 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/models"
+)
+
+func TestSummarize(t *testing.T) {
+	tests := []struct {
+		name      string
+		statuses  []string
+		latencies []float64
+		want      models.Rollup
+	}{
+		{
+			name: "empty period",
+			want: models.Rollup{WorstStatus: "OPERATIONAL"},
+		},
+		{
+			name:      "single check",
+			statuses:  []string{"OPERATIONAL"},
+			latencies: []float64{42},
+			want: models.Rollup{
+				Checks: 1, Operational: 1, WorstStatus: "OPERATIONAL",
+				MinResponseTimeMs: 42, AvgResponseTimeMs: 42, MaxResponseTimeMs: 42, P95ResponseTimeMs: 42,
+			},
+		},
+		{
+			name:      "mixed statuses",
+			statuses:  []string{"OPERATIONAL", "DOWN", "DEGRADED", "OPERATIONAL"},
+			latencies: []float64{40, 10, 30, 20},
+			want: models.Rollup{
+				Checks: 4, Operational: 2, WorstStatus: "DOWN",
+				MinResponseTimeMs: 10, AvgResponseTimeMs: 25, MaxResponseTimeMs: 40, P95ResponseTimeMs: 40,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var records []models.CheckRecord
+			for i, status := range tt.statuses {
+				records = append(records, models.CheckRecord{Status: status, InternalResponseTimeMs: tt.latencies[i]})
+			}
+
+			got := Summarize("api", models.GranularityHour, testNow, records)
+			tt.want.ServiceName = "api"
+			tt.want.Granularity = models.GranularityHour
+			tt.want.Start = testNow
+			if got != tt.want {
+				t.Errorf("Summarize() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSummarizeP95(t *testing.T) {
+	var records []models.CheckRecord
+	for i := 100; i >= 1; i-- {
+		records = append(records, models.CheckRecord{Status: "OPERATIONAL", InternalResponseTimeMs: float64(i)})
+	}
+
+	if got := Summarize("api", models.GranularityHour, testNow, records).P95ResponseTimeMs; got != 95 {
+		t.Errorf("P95ResponseTimeMs = %v, want 95", got)
+	}
+}
+
+func TestStoreResultsRollsUpCompletedPeriods(t *testing.T) {
+	ctx := context.Background()
+	backend := NewMemoryBackend()
+	backend.SetClock(func() time.Time { return testNow })
+	service := NewService(backend, 0)
+
+	store := func(at time.Time, status string, latency float64) {
+		t.Helper()
+		err := service.StoreResults(ctx, &models.MonitoringResult{
+			Components: []models.Component{{Name: "api", Status: status, ResponseTimeMs: latency}},
+			Timestamp:  at,
+		})
+		if err != nil {
+			t.Fatalf("StoreResults() error = %v", err)
+		}
+	}
+
+	// testNow is 12:00; checks during 10:00-11:00 complete that hour at 11:00
+	hour := testNow.Add(-2 * time.Hour)
+	store(hour.Add(10*time.Minute), "OPERATIONAL", 10)
+	store(hour.Add(20*time.Minute), "DOWN", 30)
+
+	if rollups, _ := backend.Rollups(ctx, "api", models.GranularityHour, hour, hour); len(rollups) != 0 {
+		t.Fatalf("hour rolled up before it completed: %+v", rollups)
+	}
+
+	store(hour.Add(70*time.Minute), "OPERATIONAL", 20)
+
+	rollups, err := backend.Rollups(ctx, "api", models.GranularityHour, hour, hour)
+	if err != nil {
+		t.Fatalf("Rollups() error = %v", err)
+	}
+	if len(rollups) != 1 {
+		t.Fatalf("got %d hourly rollups, want 1", len(rollups))
+	}
+	got := rollups[0]
+	if got.Checks != 2 || got.Operational != 1 || got.WorstStatus != "DOWN" || got.AvgResponseTimeMs != 20 {
+		t.Errorf("hourly rollup = %+v", got)
+	}
+	if want := hour.Add(rollupRetention[models.GranularityHour]); !got.ExpiresAt.Equal(want) {
+		t.Errorf("ExpiresAt = %s, want %s", got.ExpiresAt, want)
+	}
+
+	// The first check of a new day rolls up the previous one
+	store(testNow.Truncate(24*time.Hour).Add(24*time.Hour+time.Minute), "OPERATIONAL", 20)
+
+	day := testNow.Truncate(24 * time.Hour)
+	daily, _ := backend.Rollups(ctx, "api", models.GranularityDay, day, day)
+	if len(daily) != 1 || daily[0].Checks != 3 {
+		t.Errorf("daily rollups = %+v, want one covering 3 checks", daily)
+	}
+}
+
+func TestStoreResultsRollsUpAcrossLongIntervals(t *testing.T) {
+	ctx := context.Background()
+	backend := NewMemoryBackend()
+	backend.SetClock(func() time.Time { return testNow })
+	service := NewService(backend, 0)
+
+	store := func(at time.Time) {
+		t.Helper()
+		err := service.StoreResults(ctx, &models.MonitoringResult{
+			Components: []models.Component{{Name: "api", Status: "OPERATIONAL", ResponseTimeMs: 10}},
+			Timestamp:  at,
+		})
+		if err != nil {
+			t.Fatalf("StoreResults() error = %v", err)
+		}
+	}
+
+	// Every 90 minutes from 10:00 to 19:00, then nothing until 09:00 the
+	// next day
+	day := testNow.Truncate(24 * time.Hour)
+	for at := day.Add(10 * time.Hour); !at.After(day.Add(19 * time.Hour)); at = at.Add(90 * time.Minute) {
+		store(at)
+	}
+	store(day.Add(33 * time.Hour))
+
+	// Every hour from the first check until the last one is rolled up,
+	// with an empty rollup for the hours without checks
+	hourly, err := backend.Rollups(ctx, "api", models.GranularityHour, day, day.Add(33*time.Hour))
+	if err != nil {
+		t.Fatalf("Rollups() error = %v", err)
+	}
+	checked := map[int]bool{10: true, 11: true, 13: true, 14: true, 16: true, 17: true, 19: true}
+	if want := 23; len(hourly) != want {
+		t.Errorf("got %d hourly rollups, want %d", len(hourly), want)
+	}
+	for i, rollup := range hourly {
+		if want := day.Add(time.Duration(10+i) * time.Hour); !rollup.Start.Equal(want) {
+			t.Errorf("rollup %d starts at %s, want %s", i, rollup.Start, want)
+			continue
+		}
+		want := 0
+		if checked[10+i] {
+			want = 1
+		}
+		if rollup.Checks != want {
+			t.Errorf("rollup of %s covers %d checks, want %d", rollup.Start, rollup.Checks, want)
+		}
+	}
+
+	// The day before the gap is rolled up with every check
+	daily, _ := backend.Rollups(ctx, "api", models.GranularityDay, day, day)
+	if len(daily) != 1 || daily[0].Checks != 7 {
+		t.Errorf("daily rollups = %+v, want one covering 7 checks", daily)
+	}
+}
+
+// failingRollups fails PutRollups while failing is set
+type failingRollups struct {
+	*MemoryBackend
+	failing bool
+}
+
+func (f *failingRollups) PutRollups(ctx context.Context, rollups []models.Rollup) error {
+	if f.failing {
+		return context.DeadlineExceeded
+	}
+	return f.MemoryBackend.PutRollups(ctx, rollups)
+}
+
+func TestStoreResultsRetriesFailedRollupsInNewProcess(t *testing.T) {
+	ctx := context.Background()
+	backend := &failingRollups{MemoryBackend: NewMemoryBackend()}
+	backend.SetClock(func() time.Time { return testNow })
+
+	store := func(service *Service, at time.Time) {
+		t.Helper()
+		err := service.StoreResults(ctx, &models.MonitoringResult{
+			Components: []models.Component{{Name: "api", Status: "OPERATIONAL", ResponseTimeMs: 10}},
+			Timestamp:  at,
+		})
+		if err != nil {
+			t.Fatalf("StoreResults() error = %v", err)
+		}
+	}
+
+	hour := testNow.Truncate(time.Hour)
+	first := NewService(backend, 0)
+	store(first, hour.Add(-3*time.Hour+time.Minute))
+	store(first, hour.Add(-2*time.Hour+time.Minute))
+
+	// Rolling up the hour before fails, then the process goes away
+	backend.failing = true
+	store(first, hour.Add(-time.Hour+time.Minute))
+	backend.failing = false
+
+	// A new process, such as a fresh Lambda container, picks the failed
+	// hour up from the stored rollups
+	store(NewService(backend, 0), hour.Add(time.Minute))
+
+	rollups, err := backend.Rollups(ctx, "api", models.GranularityHour, hour.Add(-3*time.Hour), hour)
+	if err != nil {
+		t.Fatalf("Rollups() error = %v", err)
+	}
+	var starts []time.Time
+	for _, rollup := range rollups {
+		starts = append(starts, rollup.Start)
+		if rollup.Checks != 1 {
+			t.Errorf("rollup of %s covers %d checks, want 1", rollup.Start, rollup.Checks)
+		}
+	}
+	if len(starts) != 3 || !starts[0].Equal(hour.Add(-3*time.Hour)) || !starts[2].Equal(hour.Add(-time.Hour)) {
+		t.Errorf("hourly rollups start at %v, want the three hours before %s", starts, hour)
+	}
+}
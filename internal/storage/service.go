@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/models"
+)
+
+// Service handles storage operations
+type Service struct {
+	backend   Backend
+	retention time.Duration
+
+	// rolledUp remembers the last period rolled up per component and
+	// granularity, so warm processes skip the existence check
+	mu       sync.Mutex
+	rolledUp map[string]time.Time
+}
+
+// NewService creates a new storage service. Stored checks expire after the
+// retention period; a zero retention keeps them forever.
+func NewService(backend Backend, retention time.Duration) *Service {
+	return &Service{
+		backend:   backend,
+		retention: retention,
+		rolledUp:  make(map[string]time.Time),
+	}
+}
+
+// StoreResults appends the components of a monitoring result to the
+// backend, reading their latest stored checks first
+func (s *Service) StoreResults(ctx context.Context, result *models.MonitoringResult) error {
+	if len(result.Components) == 0 {
+		return fmt.Errorf("no components to store")
+	}
+
+	previous, err := s.LatestStored(ctx, result)
+	if err != nil {
+		log.Printf("Failed to read the previous checks, storing without them: %v", err)
+	}
+	return s.StoreResultsWithPrevious(ctx, result, previous)
+}
+
+// StoreResultsWithPrevious appends the components of a monitoring result to
+// the backend, given their latest stored checks as read by LatestStored, for
+// callers that need them too. The previous checks carry each component's
+// status over and bound the periods that may have closed since its last
+// rollup; nil stores the result as if its components were new.
+func (s *Service) StoreResultsWithPrevious(ctx context.Context, result *models.MonitoringResult, previous map[string]models.CheckRecord) error {
+	if len(result.Components) == 0 {
+		return fmt.Errorf("no components to store")
+	}
+
+	checkedAt := result.Timestamp.UTC()
+	var expiresAt time.Time
+	if s.retention > 0 {
+		expiresAt = checkedAt.Add(s.retention)
+	}
+
+	records := make([]models.CheckRecord, 0, len(result.Components))
+	names := make([]string, 0, len(result.Components))
+	for _, component := range result.Components {
+		last, seen := previous[component.Name]
+		names = append(names, component.Name)
+		records = append(records, models.CheckRecord{
+			ServiceName:            component.Name,
+			Status:                 component.Status,
+			InternalResponseTimeMs: component.ResponseTimeMs,
+			TotalResponseTimeMs:    result.TotalResponseTimeMs,
+			Message:                component.Message,
+			ErrorClass:             component.ErrorClass,
+			Certificate:            component.Certificate,
+			Steps:                  component.Steps,
+			Attempts:               result.Attempts,
+			Failures:               component.Failures,
+			StatusSince:            statusSince(last, seen, component.Status, checkedAt),
+			LastChecked:            checkedAt,
+			ExpiresAt:              expiresAt,
+		})
+	}
+
+	if err := s.backend.Append(ctx, records); err != nil {
+		return err
+	}
+
+	// Aggregates are derived data; a failure here shouldn't fail the check,
+	// and the periods are retried on the next write
+	if err := s.rollupCompleted(ctx, names, previous, checkedAt); err != nil {
+		log.Printf("Failed to update rollups: %v", err)
+	}
+
+	return nil
+}
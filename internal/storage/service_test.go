@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/models"
+)
+
+func TestStoreResultsRetention(t *testing.T) {
+	tests := []struct {
+		name      string
+		retention time.Duration
+		want      time.Time
+	}{
+		{"retention disabled", 0, time.Time{}},
+		{"expires after retention", 24 * time.Hour, testNow.Add(24 * time.Hour)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := NewMemoryBackend()
+			backend.SetClock(func() time.Time { return testNow })
+			service := NewService(backend, tt.retention)
+
+			err := service.StoreResults(context.Background(), &models.MonitoringResult{
+				Components: []models.Component{{Name: "api", Status: "OPERATIONAL"}},
+				Timestamp:  testNow,
+			})
+			if err != nil {
+				t.Fatalf("StoreResults() error = %v", err)
+			}
+
+			latest, _ := backend.Latest(context.Background())
+			if len(latest) != 1 {
+				t.Fatalf("got %d records, want 1", len(latest))
+			}
+			if !latest[0].ExpiresAt.Equal(tt.want) {
+				t.Errorf("ExpiresAt = %s, want %s", latest[0].ExpiresAt, tt.want)
+			}
+		})
+	}
+}
+
+func TestStoreResultsRequiresComponents(t *testing.T) {
+	service := NewService(NewMemoryBackend(), 0)
+	if err := service.StoreResults(context.Background(), &models.MonitoringResult{}); err == nil {
+		t.Error("StoreResults() with no components should fail")
+	}
+}
+
+func TestStoreResultsMessage(t *testing.T) {
+	backend, err := NewFileBackend(filepath.Join(t.TempDir(), "checks.jsonl"))
+	if err != nil {
+		t.Fatalf("NewFileBackend() error = %v", err)
+	}
+	service := NewService(backend, 0)
+
+	err = service.StoreResults(context.Background(), &models.MonitoringResult{
+		Components: []models.Component{{Name: "api", Status: "DEGRADED", Message: "$.db.replicationLag is 7, want < 5"}},
+		Timestamp:  time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("StoreResults() error = %v", err)
+	}
+
+	latest, _ := backend.Latest(context.Background())
+	if len(latest) != 1 || latest[0].Message != "$.db.replicationLag is 7, want < 5" {
+		t.Errorf("Latest() = %+v, want the component's message", latest)
+	}
+}
@@ -0,0 +1,40 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/models"
+)
+
+// LatestStored returns the latest stored check of each component of a
+// result, read from the backend so checks stored by other processes count.
+// Components that were never stored are left out. It's meant to be called
+// before the result is stored, to compare the two.
+func (s *Service) LatestStored(ctx context.Context, result *models.MonitoringResult) (map[string]models.CheckRecord, error) {
+	latest := make(map[string]models.CheckRecord, len(result.Components))
+	for _, component := range result.Components {
+		record, ok, err := s.backend.Last(ctx, component.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read latest checks: %w", err)
+		}
+		if ok {
+			latest[component.Name] = record
+		}
+	}
+	return latest, nil
+}
+
+// statusSince returns when a component checked at checkedAt last went from
+// operational to not, or back, carrying the time over from its previous
+// check while that side hasn't changed
+func statusSince(previous models.CheckRecord, seen bool, status string, checkedAt time.Time) time.Time {
+	if !seen || previous.StatusSince.IsZero() || previous.StatusSince.After(checkedAt) {
+		return checkedAt
+	}
+	if (previous.Status == models.StatusOperational) != (status == models.StatusOperational) {
+		return checkedAt
+	}
+	return previous.StatusSince
+}
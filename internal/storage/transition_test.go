@@ -0,0 +1,124 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/openlearnnitj/openlearn-monitoring/internal/models"
+)
+
+func TestStatusSince(t *testing.T) {
+	service := NewService(NewMemoryBackend(), 0)
+
+	tests := []struct {
+		status    string
+		wantSince int // index of the check the outage or operational spell began with
+	}{
+		{"OPERATIONAL", 0},
+		{"OPERATIONAL", 0},
+		{"DEGRADED", 2},
+		{"DOWN", 2},
+		{"OPERATIONAL", 4},
+	}
+
+	for i, tt := range tests {
+		result := &models.MonitoringResult{
+			Components: []models.Component{{Name: "api", Status: tt.status}},
+			Timestamp:  testNow.Add(time.Duration(i) * time.Minute),
+		}
+
+		latest, err := service.LatestStored(context.Background(), result)
+		if err != nil {
+			t.Fatalf("check %d: LatestStored() error = %v", i+1, err)
+		}
+		if _, ok := latest["api"]; ok != (i > 0) {
+			t.Errorf("check %d: LatestStored() = %+v", i+1, latest)
+		}
+
+		if err := service.StoreResults(context.Background(), result); err != nil {
+			t.Fatalf("check %d: StoreResults() error = %v", i+1, err)
+		}
+
+		latest, _ = service.LatestStored(context.Background(), result)
+		want := testNow.Add(time.Duration(tt.wantSince) * time.Minute)
+		if got := latest["api"]; got.Status != tt.status || !got.StatusSince.Equal(want) {
+			t.Errorf("check %d: stored %s since %s, want %s since %s", i+1, got.Status, got.StatusSince, tt.status, want)
+		}
+	}
+}
+
+func TestLatestStoredReadsBackend(t *testing.T) {
+	backend := NewMemoryBackend()
+	outageStart := testNow.Add(-time.Hour)
+	err := backend.Append(context.Background(), []models.CheckRecord{
+		{ServiceName: "api", Status: "DOWN", StatusSince: outageStart, LastChecked: testNow.Add(-time.Minute)},
+	})
+	if err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+
+	// A new process carries on the outage recorded by the previous one
+	service := NewService(backend, 0)
+	result := &models.MonitoringResult{
+		Components: []models.Component{{Name: "api", Status: "DEGRADED"}, {Name: "auth", Status: "DOWN"}},
+		Timestamp:  testNow,
+	}
+	latest, err := service.LatestStored(context.Background(), result)
+	if err != nil {
+		t.Fatalf("LatestStored() error = %v", err)
+	}
+	if len(latest) != 1 || latest["api"].Status != "DOWN" || !latest["api"].StatusSince.Equal(outageStart) {
+		t.Errorf("LatestStored() = %+v, want api DOWN since %s", latest, outageStart)
+	}
+
+	if err := service.StoreResults(context.Background(), result); err != nil {
+		t.Fatalf("StoreResults() error = %v", err)
+	}
+	records, _ := backend.Latest(context.Background())
+	for _, record := range records {
+		want := outageStart
+		if record.ServiceName == "auth" {
+			want = testNow
+		}
+		if !record.StatusSince.Equal(want) {
+			t.Errorf("%s stored since %s, want %s", record.ServiceName, record.StatusSince, want)
+		}
+	}
+}
+
+func TestLatestStoredSeesOtherWriters(t *testing.T) {
+	backend := NewMemoryBackend()
+	first, second := NewService(backend, 0), NewService(backend, 0)
+
+	store := func(service *Service, status string, at time.Time) map[string]models.CheckRecord {
+		t.Helper()
+		result := &models.MonitoringResult{
+			Components: []models.Component{{Name: "api", Status: status}},
+			Timestamp:  at,
+		}
+		latest, err := service.LatestStored(context.Background(), result)
+		if err != nil {
+			t.Fatalf("LatestStored() error = %v", err)
+		}
+		if err := service.StoreResults(context.Background(), result); err != nil {
+			t.Fatalf("StoreResults() error = %v", err)
+		}
+		return latest
+	}
+
+	// Both processes have read the backend before the other writes to it
+	store(first, "OPERATIONAL", testNow)
+	store(second, "OPERATIONAL", testNow.Add(time.Minute))
+	store(first, "DOWN", testNow.Add(2*time.Minute))
+
+	latest := store(second, "DOWN", testNow.Add(3*time.Minute))
+	if got := latest["api"]; got.Status != "DOWN" || !got.StatusSince.Equal(testNow.Add(2*time.Minute)) {
+		t.Errorf("LatestStored() = %+v, want the other process's DOWN check", got)
+	}
+
+	records, _ := backend.Latest(context.Background())
+	if len(records) != 1 || !records[0].StatusSince.Equal(testNow.Add(2*time.Minute)) {
+		t.Errorf("Latest() = %+v, want the outage timed from the other process's check", records)
+	}
+}